@@ -1,58 +1,307 @@
 package main
 
 import (
+	"context"
 	"delivery/cmd"
+	httpin "delivery/internal/adapters/in/http"
+	"delivery/internal/adapters/in/jobs"
+	postgresout "delivery/internal/adapters/out/postgres"
+	"delivery/internal/adapters/out/postgres/migrations"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/logging"
+	"delivery/internal/pkg/metrics"
+	"errors"
 	"fmt"
-	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
+	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 )
 
+// httpShutdownTimeout — сколько ждем завершения запросов, уже принятых сервером
+const httpShutdownTimeout = 10 * time.Second
+
 func main() {
-	config := getConfigs()
+	config, args, err := cmd.LoadConfig(os.Args[1:], os.LookupEnv)
+	if err != nil {
+		fatal("cannot load config", err)
+	}
+
+	logger, err := logging.New(os.Stdout, logging.Config{Level: config.LogLevel, Format: config.LogFormat})
+	if err != nil {
+		fatal("cannot create logger", err)
+	}
+	// Через slog пойдут и сообщения библиотек, пишущих в стандартный log
+	slog.SetDefault(logger)
+
+	connectionString, err := makeConnectionString(
+		config.DbHost,
+		config.DbPort,
+		config.DbUser,
+		config.DbPassword,
+		config.DbName,
+		config.DbSslMode)
+	if err != nil {
+		fatal("invalid connection string", err)
+	}
+
+	gormDb := mustGormOpen(connectionString)
+
+	if len(args) > 0 && args[0] == "migrate" {
+		runMigrate(gormDb, args[1:])
+		return
+	}
+	mustMigrate(gormDb)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	compositionRoot := cmd.NewCompositionRoot(
 		config,
+		gormDb,
+		logger,
 	)
-	defer compositionRoot.CloseAll()
 
-	startWebServer(compositionRoot, config.HttpPort)
+	// Closers закрываются в обратном порядке: сначала consumers и jobs, затем
+	// producer, которым пользуется outbox relay, и только потом БД
+	sqlDb, err := gormDb.DB()
+	if err != nil {
+		fatal("cannot get sql.DB from gorm", err)
+	}
+	compositionRoot.RegisterCloser(sqlDb)
+
+	orderProducer := compositionRoot.NewOrderProducer()
+	compositionRoot.RegisterCloser(orderProducer)
+	compositionRoot.SubscribeDomainEventHandlers(orderProducer)
+
+	startJobs(ctx, &compositionRoot)
+	startConsumers(ctx, &compositionRoot)
+
+	e := newWebServer(&compositionRoot, logger)
+	serverErrors := make(chan error, 1)
+	logger.Info("HTTP server started", slog.Int("port", config.HttpPort))
+	go func() {
+		serverErrors <- e.Start(fmt.Sprintf("0.0.0.0:%d", config.HttpPort))
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Info("shutdown signal received")
+	case err := <-serverErrors:
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("HTTP server stopped", slog.Any("error", err))
+		}
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		logger.Error("cannot shutdown HTTP server", slog.Any("error", err))
+	}
+
+	if err := compositionRoot.CloseAll(); err != nil {
+		logger.Error("cannot close resources", slog.Any("error", err))
+		os.Exit(1)
+	}
+	logger.Info("service stopped")
+}
+
+// fatal пишет ошибку запуска в лог по умолчанию и завершает процесс
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
 }
 
-func getConfigs() cmd.Config {
-	config := cmd.Config{
-		HttpPort:                  goDotEnvVariable("HTTP_PORT"),
-		DbHost:                    goDotEnvVariable("DB_HOST"),
-		DbPort:                    goDotEnvVariable("DB_PORT"),
-		DbUser:                    goDotEnvVariable("DB_USER"),
-		DbPassword:                goDotEnvVariable("DB_PASSWORD"),
-		DbName:                    goDotEnvVariable("DB_NAME"),
-		DbSslMode:                 goDotEnvVariable("DB_SSLMODE"),
-		GeoServiceGrpcHost:        goDotEnvVariable("GEO_SERVICE_GRPC_HOST"),
-		KafkaHost:                 goDotEnvVariable("KAFKA_HOST"),
-		KafkaConsumerGroup:        goDotEnvVariable("KAFKA_CONSUMER_GROUP"),
-		KafkaBasketConfirmedTopic: goDotEnvVariable("KAFKA_BASKET_CONFIRMED_TOPIC"),
-		KafkaOrderChangedTopic:    goDotEnvVariable("KAFKA_ORDER_CHANGED_TOPIC"),
+func makeConnectionString(host string, port int, user string,
+	password string, dbName string, sslMode string) (string, error) {
+	if host == "" {
+		return "", errs.NewValueIsRequiredError("host")
+	}
+	if port == 0 {
+		return "", errs.NewValueIsRequiredError("port")
 	}
-	return config
+	if user == "" {
+		return "", errs.NewValueIsRequiredError("user")
+	}
+	if password == "" {
+		return "", errs.NewValueIsRequiredError("password")
+	}
+	if dbName == "" {
+		return "", errs.NewValueIsRequiredError("dbName")
+	}
+	if sslMode == "" {
+		return "", errs.NewValueIsRequiredError("sslMode")
+	}
+
+	return fmt.Sprintf("host=%v port=%v user=%v password=%v dbname=%v sslmode=%v",
+		host,
+		port,
+		user,
+		password,
+		dbName,
+		sslMode), nil
 }
 
-func goDotEnvVariable(key string) string {
-	err := godotenv.Load(".env")
+func mustGormOpen(connectionString string) *gorm.DB {
+	gormDb, err := gorm.Open(postgres.Open(connectionString), &gorm.Config{})
 	if err != nil {
-		log.Fatalf("Error loading .env file")
+		fatal("connection to postgres through gorm", err)
 	}
-	return os.Getenv(key)
+	if err := gormDb.Use(postgresout.NewTracingPlugin()); err != nil {
+		fatal("cannot register gorm tracing plugin", err)
+	}
+	return gormDb
 }
 
-func startWebServer(_ cmd.CompositionRoot, port string) {
+func newMigrator(db *gorm.DB) *migrations.Migrator {
+	sqlDb, err := db.DB()
+	if err != nil {
+		fatal("cannot get sql.DB from gorm", err)
+	}
+
+	migrator, err := migrations.NewMigrator(sqlDb)
+	if err != nil {
+		fatal("cannot create Migrator", err)
+	}
+	return migrator
+}
+
+func mustMigrate(db *gorm.DB) {
+	if _, err := newMigrator(db).Up(context.Background()); err != nil {
+		fatal("Ошибка миграции", err)
+	}
+}
+
+// runMigrate обрабатывает режим "migrate up|down|status"
+func runMigrate(db *gorm.DB, args []string) {
+	if len(args) != 1 {
+		fatal("usage: migrate up|down|status", fmt.Errorf("got %d arguments", len(args)))
+	}
+
+	ctx := context.Background()
+	migrator := newMigrator(db)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			fatal("Ошибка миграции", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no migrations to apply")
+		}
+		for _, version := range applied {
+			fmt.Printf("applied %d\n", version)
+		}
+	case "down":
+		version, err := migrator.Down(ctx)
+		if err != nil {
+			fatal("Ошибка отката миграции", err)
+		}
+		fmt.Printf("rolled back %d\n", version)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fatal("Ошибка получения статуса миграций", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		fatal("unknown migrate command, expected up|down|status", fmt.Errorf("unknown command %q", args[0]))
+	}
+}
+
+func startJobs(ctx context.Context, compositionRoot *cmd.CompositionRoot) {
+	runners := []*jobs.Runner{
+		compositionRoot.NewAssignOrdersJob(),
+		compositionRoot.NewMoveCouriersJob(),
+		compositionRoot.NewOutboxRelayJob(),
+		compositionRoot.NewRefreshCostMapJob(),
+	}
+
+	for _, runner := range runners {
+		runner.Start(ctx)
+		compositionRoot.RegisterCloser(runner)
+	}
+}
+
+func startConsumers(ctx context.Context, compositionRoot *cmd.CompositionRoot) {
+	basketConfirmedConsumer := compositionRoot.NewBasketConfirmedConsumer()
+	basketConfirmedConsumer.Start(ctx)
+	compositionRoot.RegisterCloser(basketConfirmedConsumer)
+}
+
+func newWebServer(compositionRoot *cmd.CompositionRoot, logger *slog.Logger) *echo.Echo {
+	handlers, err := httpin.NewServer(
+		compositionRoot.NewCreateOrderCommandHandler(),
+		compositionRoot.NewCreateCourierCommandHandler(),
+		compositionRoot.NewGetAllCouriersQueryHandler(),
+		compositionRoot.NewGetNotCompletedOrdersQueryHandler(),
+		compositionRoot.NewSetTrafficZoneCommandHandler(),
+		compositionRoot.NewRemoveTrafficZoneCommandHandler(),
+		compositionRoot.NewGetTrafficZonesQueryHandler(),
+		logger,
+	)
+	if err != nil {
+		fatal("Ошибка инициализации HTTP Server", err)
+	}
+
 	e := echo.New()
-	e.GET("/health", func(c echo.Context) error {
-		return c.String(http.StatusOK, "Healthy")
-	})
+	// Баннер и адрес Echo печатает мимо логгера, ломая JSON-вывод
+	e.HideBanner = true
+	e.HidePort = true
+	e.Use(middleware.CORS())
+	e.Use(otelecho.Middleware("delivery", otelecho.WithSkipper(func(c echo.Context) bool {
+		// Опросы Prometheus и проверки живости только засоряли бы трассы
+		path := c.Request().URL.Path
+		return path == "/metrics" || strings.HasPrefix(path, "/health")
+	})))
+	e.Use(httpin.CorrelationMiddleware())
+	e.Use(httpin.RequestLogger(logger))
+	e.Use(metrics.EchoMiddleware())
 
-	e.Logger.Fatal(e.Start(fmt.Sprintf("0.0.0.0:%s", port)))
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", port), nil))
+	compositionRoot.RegisterStateMetrics()
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+
+	healthHandler, err := httpin.NewHealthHandler(compositionRoot.NewHealthRegistry())
+	if err != nil {
+		fatal("Ошибка инициализации health checks", err)
+	}
+	healthHandler.Register(e)
+
+	registerSwaggerOpenApi(e)
+	servers.RegisterHandlers(e, servers.NewStrictHandler(handlers, nil))
+
+	return e
+}
+
+func registerSwaggerOpenApi(e *echo.Echo) {
+	e.GET("/openapi.json", func(c echo.Context) error {
+		swagger, err := servers.GetSwagger()
+		if err != nil {
+			return c.String(http.StatusInternalServerError, "failed to load swagger: "+err.Error())
+		}
+
+		data, err := swagger.MarshalJSON()
+		if err != nil {
+			return c.String(http.StatusInternalServerError, "failed to marshal swagger: "+err.Error())
+		}
+
+		return c.Blob(http.StatusOK, "application/json", data)
+	})
 }
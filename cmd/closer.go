@@ -1,6 +1,14 @@
 package cmd
 
-import "log"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// closerTimeout ограничивает закрытие одного ресурса, чтобы зависший ресурс
+// не задерживал остановку остальных
+const closerTimeout = 10 * time.Second
 
 type Closer interface {
 	Close() error
@@ -10,10 +18,41 @@ func (cr *CompositionRoot) RegisterCloser(c Closer) {
 	cr.closers = append(cr.closers, c)
 }
 
-func (cr *CompositionRoot) CloseAll() {
-	for _, closer := range cr.closers {
-		if err := closer.Close(); err != nil {
-			log.Printf("error closing resource: %v", err)
+// CloseAll закрывает ресурсы в порядке, обратном регистрации: сначала то, что
+// запущено последним и зависит от остального (consumers, jobs), затем их
+// зависимости (producer, БД). Ошибки всех ресурсов собираются в одну.
+func (cr *CompositionRoot) CloseAll() error {
+	closers := cr.closers
+	cr.closers = nil
+	return closeAll(closers, closerTimeout)
+}
+
+func closeAll(closers []Closer, timeout time.Duration) error {
+	var result []error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closeWithTimeout(closers[i], timeout); err != nil {
+			result = append(result, err)
+		}
+	}
+	return errors.Join(result...)
+}
+
+func closeWithTimeout(closer Closer, timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- closer.Close()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("cannot close %T: %w", closer, err)
 		}
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("cannot close %T: timed out after %s", closer, timeout)
 	}
 }
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingCloser struct {
+	name   string
+	closed *[]string
+	err    error
+	delay  time.Duration
+}
+
+func (c recordingCloser) Close() error {
+	time.Sleep(c.delay)
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+func TestCloseAll_ClosesInReverseOrder(t *testing.T) {
+	var closed []string
+	cr := &CompositionRoot{}
+	cr.RegisterCloser(recordingCloser{name: "db", closed: &closed})
+	cr.RegisterCloser(recordingCloser{name: "producer", closed: &closed})
+	cr.RegisterCloser(recordingCloser{name: "consumer", closed: &closed})
+
+	assert.NoError(t, cr.CloseAll())
+	assert.Equal(t, []string{"consumer", "producer", "db"}, closed)
+
+	// Повторный вызов ничего не закрывает второй раз
+	assert.NoError(t, cr.CloseAll())
+	assert.Len(t, closed, 3)
+}
+
+func TestCloseAll_AggregatesErrors(t *testing.T) {
+	var closed []string
+	firstErr := errors.New("first")
+	secondErr := errors.New("second")
+
+	err := closeAll([]Closer{
+		recordingCloser{name: "a", closed: &closed, err: firstErr},
+		recordingCloser{name: "b", closed: &closed},
+		recordingCloser{name: "c", closed: &closed, err: secondErr},
+	}, time.Second)
+
+	assert.ErrorIs(t, err, firstErr)
+	assert.ErrorIs(t, err, secondErr)
+	assert.Equal(t, []string{"c", "b", "a"}, closed)
+}
+
+func TestCloseAll_TimesOutHangingCloser(t *testing.T) {
+	var hung, rest []string
+
+	start := time.Now()
+	err := closeAll([]Closer{
+		recordingCloser{name: "rest", closed: &rest},
+		recordingCloser{name: "hung", closed: &hung, delay: time.Second},
+	}, 20*time.Millisecond)
+
+	assert.ErrorContains(t, err, "timed out")
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, []string{"rest"}, rest)
+}
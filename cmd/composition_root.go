@@ -1,13 +1,517 @@
 package cmd
 
+import (
+	"context"
+	"delivery/internal/adapters/in/jobs"
+	kafkain "delivery/internal/adapters/in/kafka"
+	"delivery/internal/adapters/out/grpc/geo"
+	"delivery/internal/adapters/out/instrumented"
+	kafkaout "delivery/internal/adapters/out/kafka"
+	"delivery/internal/adapters/out/postgres"
+	"delivery/internal/adapters/out/postgres/outboxrepo"
+	"delivery/internal/adapters/out/postgres/trafficzonerepo"
+	"delivery/internal/core/application/costmap"
+	"delivery/internal/core/application/eventhandlers"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/core/domain/services"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/health"
+	"delivery/internal/pkg/logging"
+	"delivery/internal/pkg/metrics"
+	"delivery/internal/pkg/outbox"
+	"delivery/internal/pkg/random"
+	"delivery/internal/pkg/tracing"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	serviceName         = "delivery"
+	healthCheckTimeout  = 2 * time.Second
+	outboxMaxPendingAge = time.Minute
+	kafkaHealthCacheTTL = 15 * time.Second
+)
+
+// fatal сообщает об ошибке сборки приложения и завершает процесс: без этих
+// зависимостей сервис работать не может
+func fatal(logger *slog.Logger, msg string, err error, attrs ...any) {
+	logger.Error(msg, append([]any{slog.Any("error", err)}, attrs...)...)
+	os.Exit(1)
+}
+
 type CompositionRoot struct {
 	configs Config
+	gormDb  *gorm.DB
+
+	mediatr       ddd.Mediatr
+	eventRegistry outbox.EventRegistry
+	geoClient     *geo.Client
+	grid          kernel.Grid
+	cityMap       kernel.CityMap
+	costMapHolder *costmap.Holder
+	distance      kernel.Distance
+	clock         clock.Clock
+	randomSource  random.Source
+	logger        *slog.Logger
 
 	closers []Closer
 }
 
-func NewCompositionRoot(configs Config) CompositionRoot {
+func NewCompositionRoot(configs Config, gormDb *gorm.DB, logger *slog.Logger) CompositionRoot {
+	// Провайдер настраивается первым: клиенты ниже берут глобальный TracerProvider
+	tracingProvider, err := tracing.NewProvider(context.Background(), tracing.Config{
+		ServiceName:  serviceName,
+		Exporter:     configs.TracingExporter,
+		OtlpEndpoint: configs.TracingOtlpEndpoint,
+	})
+	if err != nil {
+		fatal(logger, "cannot create tracing Provider", err)
+	}
+
+	eventRegistry, err := outbox.NewEventRegistry()
+	if err != nil {
+		fatal(logger, "cannot create EventRegistry", err)
+	}
+	if err := registerDomainEvents(eventRegistry); err != nil {
+		fatal(logger, "cannot register domain events", err)
+	}
+
+	grid, err := kernel.NewGridOfSize(configs.GridWidth, configs.GridHeight)
+	if err != nil {
+		fatal(logger, "cannot create city Grid", err)
+	}
+	cityMap, err := newCityMap(grid, configs.MapBlockedCells)
+	if err != nil {
+		fatal(logger, "cannot create CityMap", err)
+	}
+	trafficZoneRepository, err := trafficzonerepo.NewRepository(gormDb)
+	if err != nil {
+		fatal(logger, "cannot create traffic zone Repository", err)
+	}
+	costMapHolder, err := costmap.NewHolder(trafficZoneRepository)
+	if err != nil {
+		fatal(logger, "cannot create cost map Holder", err)
+	}
+	// Без районов из БД курьеры ездили бы сквозь пробки до первого обновления
+	if err := costMapHolder.Refresh(context.Background()); err != nil {
+		fatal(logger, "cannot load traffic zones", err)
+	}
+	distance, err := newDistance(configs, cityMap, costMapHolder)
+	if err != nil {
+		fatal(logger, "cannot create Distance", err, slog.String("mode", configs.DistanceMode))
+	}
+
+	// Одно соединение с Geo на все приложение: gRPC сам мультиплексирует вызовы
+	geoClient, err := geo.NewClient(configs.GeoServiceGrpcHost, grid)
+	if err != nil {
+		fatal(logger, "cannot create geo Client", err)
+	}
+
+	// Идентификаторы агрегатов, событий и сообщений outbox выдает uuid.New:
+	// берем для него тот же источник, иначе RANDOM_SEED не повторял бы прогон
+	randomSource := newRandomSource(configs.RandomSeed)
+	uuid.SetRand(randomSource)
+
 	return CompositionRoot{
-		configs: configs,
+		configs:       configs,
+		gormDb:        gormDb,
+		mediatr:       ddd.NewMediatr(),
+		eventRegistry: eventRegistry,
+		geoClient:     geoClient,
+		grid:          grid,
+		cityMap:       cityMap,
+		costMapHolder: costMapHolder,
+		distance:      distance,
+		clock:         clock.System(),
+		randomSource:  randomSource,
+		logger:        logger,
+		closers:       []Closer{tracingProvider, geoClient},
+	}
+}
+
+func (cr *CompositionRoot) NewUnitOfWorkFactory() ports.UnitOfWorkFactory {
+	unitOfWorkFactory, err := postgres.NewUnitOfWorkFactory(cr.gormDb, cr.clock)
+	if err != nil {
+		fatal(cr.logger, "cannot create UnitOfWorkFactory", err)
+	}
+	return unitOfWorkFactory
+}
+
+func (cr *CompositionRoot) NewGeoClient() ports.GeoClient {
+	return cr.geoClient
+}
+
+// handlerDecorators оборачивают каждый use case: span снаружи, чтобы запись
+// лога получила trace_id. Отсутствие свободного курьера — обычный исход тика
+// назначения, и в трассах оно не считается ошибкой.
+func (cr *CompositionRoot) handlerDecorators() []ddd.HandlerDecorator {
+	return []ddd.HandlerDecorator{
+		tracing.HandlerDecorator(commands.ErrNoSuitableCourier),
+		logging.HandlerDecorator(cr.logger),
+	}
+}
+
+func (cr *CompositionRoot) NewCreateOrderCommandHandler() commands.CreateOrderCommandHandler {
+	commandHandler, err := commands.NewCreateOrderCommandHandler(cr.NewUnitOfWorkFactory(), cr.NewGeoClient(), cr.cityMap)
+	if err != nil {
+		fatal(cr.logger, "cannot create CreateOrderCommandHandler", err)
+	}
+	return ddd.DecorateCommandHandler("CreateOrderCommandHandler", commandHandler, cr.handlerDecorators()...)
+}
+
+func (cr *CompositionRoot) NewCreateCourierCommandHandler() commands.CreateCourierCommandHandler {
+	commandHandler, err := commands.NewCreateCourierCommandHandler(cr.NewUnitOfWorkFactory(), cr.cityMap, cr.randomSource)
+	if err != nil {
+		fatal(cr.logger, "cannot create CreateCourierCommandHandler", err)
+	}
+	return ddd.DecorateCommandHandler("CreateCourierCommandHandler", commandHandler, cr.handlerDecorators()...)
+}
+
+func (cr *CompositionRoot) NewGetAllCouriersQueryHandler() queries.GetAllCouriersQueryHandler {
+	queryHandler, err := queries.NewGetAllCouriersQueryHandler(cr.gormDb)
+	if err != nil {
+		fatal(cr.logger, "cannot create GetAllCouriersQueryHandler", err)
+	}
+	return ddd.DecorateQueryHandler("GetAllCouriersQueryHandler", queryHandler, cr.handlerDecorators()...)
+}
+
+func (cr *CompositionRoot) NewGetNotCompletedOrdersQueryHandler() queries.GetNotCompletedOrdersQueryHandler {
+	queryHandler, err := queries.NewGetNotCompletedOrdersQueryHandler(cr.gormDb)
+	if err != nil {
+		fatal(cr.logger, "cannot create GetNotCompletedOrdersQueryHandler", err)
+	}
+	return ddd.DecorateQueryHandler("GetNotCompletedOrdersQueryHandler", queryHandler, cr.handlerDecorators()...)
+}
+
+func (cr *CompositionRoot) NewOrderDispatcher() services.OrderDispatcher {
+	orderDispatcher, err := services.NewOrderDispatcher(cr.distance)
+	if err != nil {
+		fatal(cr.logger, "cannot create OrderDispatcher", err)
+	}
+	return instrumented.NewOrderDispatcher(orderDispatcher)
+}
+
+func (cr *CompositionRoot) NewAssignOrdersCommandHandler() commands.AssignOrdersCommandHandler {
+	commandHandler, err := commands.NewAssignOrdersCommandHandler(cr.NewUnitOfWorkFactory(), cr.cityMap, cr.NewOrderDispatcher())
+	if err != nil {
+		fatal(cr.logger, "cannot create AssignOrdersCommandHandler", err)
+	}
+	return ddd.DecorateCommandHandler("AssignOrdersCommandHandler", commandHandler, cr.handlerDecorators()...)
+}
+
+func (cr *CompositionRoot) NewAssignOrdersJob() *jobs.Runner {
+	job, err := jobs.NewAssignOrdersJob(cr.NewAssignOrdersCommandHandler(), cr.logger)
+	if err != nil {
+		fatal(cr.logger, "cannot create AssignOrdersJob", err)
+	}
+
+	runner, err := jobs.NewRunner("assign-orders", cr.configs.AssignOrdersInterval, job, cr.logger)
+	if err != nil {
+		fatal(cr.logger, "cannot create assign orders Runner", err)
+	}
+	return runner
+}
+
+func (cr *CompositionRoot) NewMoveCouriersCommandHandler() commands.MoveCouriersCommandHandler {
+	commandHandler, err := commands.NewMoveCouriersCommandHandler(cr.NewUnitOfWorkFactory(), cr.cityMap, cr.costMapHolder, cr.logger)
+	if err != nil {
+		fatal(cr.logger, "cannot create MoveCouriersCommandHandler", err)
+	}
+	return ddd.DecorateCommandHandler("MoveCouriersCommandHandler", commandHandler, cr.handlerDecorators()...)
+}
+
+func (cr *CompositionRoot) NewMoveCouriersJob() *jobs.Runner {
+	job, err := jobs.NewMoveCouriersJob(cr.NewMoveCouriersCommandHandler())
+	if err != nil {
+		fatal(cr.logger, "cannot create MoveCouriersJob", err)
+	}
+
+	runner, err := jobs.NewRunner("move-couriers", cr.configs.MoveCouriersInterval, job, cr.logger)
+	if err != nil {
+		fatal(cr.logger, "cannot create move couriers Runner", err)
+	}
+	return runner
+}
+
+func (cr *CompositionRoot) NewTrafficZoneRepository() ports.TrafficZoneRepository {
+	repository, err := trafficzonerepo.NewRepository(cr.gormDb)
+	if err != nil {
+		fatal(cr.logger, "cannot create traffic zone Repository", err)
+	}
+	return repository
+}
+
+func (cr *CompositionRoot) NewSetTrafficZoneCommandHandler() commands.SetTrafficZoneCommandHandler {
+	commandHandler, err := commands.NewSetTrafficZoneCommandHandler(cr.NewTrafficZoneRepository(), cr.grid, cr.costMapHolder)
+	if err != nil {
+		fatal(cr.logger, "cannot create SetTrafficZoneCommandHandler", err)
+	}
+	return ddd.DecorateCommandHandler("SetTrafficZoneCommandHandler", commandHandler, cr.handlerDecorators()...)
+}
+
+func (cr *CompositionRoot) NewRemoveTrafficZoneCommandHandler() commands.RemoveTrafficZoneCommandHandler {
+	commandHandler, err := commands.NewRemoveTrafficZoneCommandHandler(cr.NewTrafficZoneRepository(), cr.costMapHolder)
+	if err != nil {
+		fatal(cr.logger, "cannot create RemoveTrafficZoneCommandHandler", err)
+	}
+	return ddd.DecorateCommandHandler("RemoveTrafficZoneCommandHandler", commandHandler, cr.handlerDecorators()...)
+}
+
+func (cr *CompositionRoot) NewGetTrafficZonesQueryHandler() queries.GetTrafficZonesQueryHandler {
+	queryHandler, err := queries.NewGetTrafficZonesQueryHandler(cr.gormDb)
+	if err != nil {
+		fatal(cr.logger, "cannot create GetTrafficZonesQueryHandler", err)
+	}
+	return ddd.DecorateQueryHandler("GetTrafficZonesQueryHandler", queryHandler, cr.handlerDecorators()...)
+}
+
+func (cr *CompositionRoot) NewRefreshCostMapJob() *jobs.Runner {
+	job, err := jobs.NewRefreshCostMapJob(cr.costMapHolder)
+	if err != nil {
+		fatal(cr.logger, "cannot create RefreshCostMapJob", err)
+	}
+
+	runner, err := jobs.NewRunner("refresh-cost-map", cr.configs.TrafficRefreshInterval, job, cr.logger)
+	if err != nil {
+		fatal(cr.logger, "cannot create refresh cost map Runner", err)
+	}
+	return runner
+}
+
+func (cr *CompositionRoot) NewOutboxRelayJob() *jobs.Runner {
+	repository, err := outboxrepo.NewRepository(cr.gormDb)
+	if err != nil {
+		fatal(cr.logger, "cannot create outbox Repository", err)
+	}
+
+	relay, err := outbox.NewRelay(repository, cr.eventRegistry, cr.mediatr, cr.configs.OutboxRelayBatchSize, cr.configs.OutboxMaxAttempts, cr.clock)
+	if err != nil {
+		fatal(cr.logger, "cannot create outbox Relay", err)
+	}
+
+	job, err := jobs.NewOutboxRelayJob(relay)
+	if err != nil {
+		fatal(cr.logger, "cannot create OutboxRelayJob", err)
+	}
+
+	runner, err := jobs.NewRunner("outbox-relay", cr.configs.OutboxRelayInterval, job, cr.logger)
+	if err != nil {
+		fatal(cr.logger, "cannot create outbox relay Runner", err)
+	}
+	return runner
+}
+
+func (cr *CompositionRoot) NewBasketConfirmedConsumer() *kafkain.BasketConfirmedConsumer {
+	consumer, err := kafkain.NewBasketConfirmedConsumer(
+		cr.configs.KafkaBrokers(),
+		cr.configs.KafkaConsumerGroup,
+		cr.configs.KafkaBasketConfirmedTopic,
+		cr.configs.KafkaBasketConfirmedDLQ,
+		cr.NewCreateOrderCommandHandler(),
+		cr.logger,
+	)
+	if err != nil {
+		fatal(cr.logger, "cannot create BasketConfirmedConsumer", err)
+	}
+	return consumer
+}
+
+func (cr *CompositionRoot) NewOrderProducer() *kafkaout.OrderProducer {
+	producer, err := kafkaout.NewOrderProducer(
+		cr.configs.KafkaBrokers(),
+		cr.configs.KafkaOrderChangedTopic,
+	)
+	if err != nil {
+		fatal(cr.logger, "cannot create OrderProducer", err)
+	}
+	return producer
+}
+
+func (cr *CompositionRoot) NewOrderStatusChangedDomainEventHandler(orderProducer ports.OrderProducer) ddd.EventHandler {
+	eventHandler, err := eventhandlers.NewOrderStatusChangedDomainEventHandler(orderProducer)
+	if err != nil {
+		fatal(cr.logger, "cannot create OrderStatusChangedDomainEventHandler", err)
+	}
+	return eventHandler
+}
+
+// SubscribeDomainEventHandlers подписывает обработчики на события, которые
+// outbox relay достает из БД. Вызывать до запуска relay: Mediatr не
+// рассчитан на подписку во время публикации.
+func (cr *CompositionRoot) SubscribeDomainEventHandlers(orderProducer ports.OrderProducer) {
+	err := subscribeDomainEventHandlers(cr.mediatr, cr.eventRegistry, cr.NewOrderStatusChangedDomainEventHandler(orderProducer))
+	if err != nil {
+		fatal(cr.logger, "cannot subscribe domain event handlers", err)
+	}
+}
+
+// subscribeDomainEventHandlers отказывает в подписке на событие, которого нет
+// в EventRegistry: relay не сможет его декодировать, и обработчик молча
+// никогда не будет вызван.
+func subscribeDomainEventHandlers(mediatr ddd.Mediatr, eventRegistry outbox.EventRegistry, orderStatusChangedHandler ddd.EventHandler) error {
+	orderStatusEvents := []ddd.DomainEvent{
+		&order.OrderCreatedDomainEvent{},
+		&order.OrderAssignedDomainEvent{},
+		&order.OrderCompletedDomainEvent{},
+		&order.OrderReleasedDomainEvent{},
+	}
+
+	for _, event := range orderStatusEvents {
+		if !eventRegistry.IsRegistered(event.GetName()) {
+			return fmt.Errorf("domain event %s is not registered", event.GetName())
+		}
+	}
+	mediatr.Subscribe(orderStatusChangedHandler, orderStatusEvents...)
+	return nil
+}
+
+func (cr *CompositionRoot) NewHealthRegistry() *health.Registry {
+	registry, err := health.NewRegistry(healthCheckTimeout)
+	if err != nil {
+		fatal(cr.logger, "cannot create health Registry", err)
+	}
+
+	postgresChecker, err := postgres.NewHealthChecker(cr.gormDb)
+	if err != nil {
+		fatal(cr.logger, "cannot create postgres HealthChecker", err)
+	}
+
+	kafkaChecker, err := kafkaout.NewHealthChecker(
+		cr.configs.KafkaBrokers(),
+		[]string{
+			cr.configs.KafkaBasketConfirmedTopic,
+			cr.configs.KafkaBasketConfirmedDLQ,
+			cr.configs.KafkaOrderChangedTopic,
+		},
+		kafkaHealthCacheTTL,
+		cr.clock,
+	)
+	if err != nil {
+		fatal(cr.logger, "cannot create kafka HealthChecker", err)
+	}
+
+	outboxRepository, err := outboxrepo.NewRepository(cr.gormDb)
+	if err != nil {
+		fatal(cr.logger, "cannot create outbox Repository", err)
+	}
+	outboxChecker, err := outbox.NewBacklogChecker(outboxRepository, outboxMaxPendingAge, cr.clock)
+	if err != nil {
+		fatal(cr.logger, "cannot create outbox BacklogChecker", err)
+	}
+
+	// Готовность определяет только Postgres: без БД сервис не выполнит ни одной
+	// команды. Отказ брокера поглощает outbox, поэтому Kafka, geo и очередь
+	// outbox видны в отчете, но не выводят экземпляр из балансировки.
+	if err := registry.Register("postgres", postgresChecker); err != nil {
+		fatal(cr.logger, "cannot register health checker", err, slog.String("checker", "postgres"))
+	}
+	optionalCheckers := map[string]health.Checker{
+		"kafka":  kafkaChecker,
+		"geo":    cr.geoClient,
+		"outbox": outboxChecker,
+	}
+	for name, checker := range optionalCheckers {
+		if err := registry.RegisterOptional(name, checker); err != nil {
+			fatal(cr.logger, "cannot register health checker", err, slog.String("checker", name))
+		}
+	}
+	return registry
+}
+
+// RegisterStateMetrics добавляет в /metrics состояние заказов, курьеров и outbox
+func (cr *CompositionRoot) RegisterStateMetrics() {
+	statsReader, err := postgres.NewStatsReader(cr.gormDb)
+	if err != nil {
+		fatal(cr.logger, "cannot create StatsReader", err)
+	}
+
+	outboxRepository, err := outboxrepo.NewRepository(cr.gormDb)
+	if err != nil {
+		fatal(cr.logger, "cannot create outbox Repository", err)
+	}
+
+	collector, err := metrics.NewStateCollector(statsReader, outboxRepository, cr.clock)
+	if err != nil {
+		fatal(cr.logger, "cannot create metrics StateCollector", err)
+	}
+	if err := metrics.Registry.Register(collector); err != nil {
+		fatal(cr.logger, "cannot register metrics StateCollector", err)
+	}
+}
+
+// registerDomainEvents перечисляет события, которые можно прочитать из outbox.
+// Незарегистрированное событие relay не сможет декодировать.
+func registerDomainEvents(eventRegistry outbox.EventRegistry) error {
+	eventTypes := []reflect.Type{
+		reflect.TypeOf(order.OrderCreatedDomainEvent{}),
+		reflect.TypeOf(order.OrderAssignedDomainEvent{}),
+		reflect.TypeOf(order.OrderCompletedDomainEvent{}),
+		reflect.TypeOf(order.OrderReleasedDomainEvent{}),
+		reflect.TypeOf(courier.CourierCreatedDomainEvent{}),
+		reflect.TypeOf(courier.CourierMovedDomainEvent{}),
+		reflect.TypeOf(courier.StoragePlaceAddedDomainEvent{}),
+		reflect.TypeOf(courier.OrderTakenDomainEvent{}),
+		reflect.TypeOf(courier.OrderDeliveredDomainEvent{}),
+		reflect.TypeOf(courier.OrderDroppedDomainEvent{}),
+	}
+
+	for _, eventType := range eventTypes {
+		if err := eventRegistry.RegisterDomainEvent(eventType); err != nil {
+			return fmt.Errorf("cannot register domain event %s: %w", eventType.Name(), err)
+		}
+	}
+	return nil
+}
+
+// newCityMap отмечает на сетке непроходимые клетки из MAP_BLOCKED_CELLS
+func newCityMap(grid kernel.Grid, blockedCells []CellArea) (kernel.CityMap, error) {
+	var blocked []kernel.Location
+	for _, area := range blockedCells {
+		for x := area.MinX; x <= area.MaxX; x++ {
+			for y := area.MinY; y <= area.MaxY; y++ {
+				location, err := kernel.NewLocation(grid, x, y)
+				if err != nil {
+					return kernel.CityMap{}, fmt.Errorf("blocked cell %d,%d: %w", x, y, err)
+				}
+				blocked = append(blocked, location)
+			}
+		}
+	}
+	return kernel.NewCityMap(grid, blocked)
+}
+
+// newDistance выбирает меру расстояния: стоимость маршрута по карте города в
+// обход препятствий и с учетом пробок (по умолчанию) или оценку по прямой
+// между клетками, приближенно спроецированными на карту из точки MAP_ORIGIN
+func newDistance(configs Config, cityMap kernel.CityMap, costs kernel.CostMapSource) (kernel.Distance, error) {
+	if configs.DistanceMode != DistanceModeGeo {
+		return kernel.NewPathDistance(cityMap, costs)
+	}
+
+	origin, err := kernel.NewGeoLocation(configs.MapOriginLatitude, configs.MapOriginLongitude)
+	if err != nil {
+		return nil, err
+	}
+	return kernel.NewGeoDistance(origin, configs.MapCellSize)
+}
+
+// newRandomSource засевает источник из RANDOM_SEED, чтобы прогон можно было
+// повторить. Без seed источник случайный.
+func newRandomSource(seed *int64) random.Source {
+	if seed == nil {
+		return random.System()
 	}
+	return random.NewSeeded(*seed)
 }
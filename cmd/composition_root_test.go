@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/outbox"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingEventHandler struct {
+	events []ddd.DomainEvent
+}
+
+func (h *recordingEventHandler) Handle(_ context.Context, event ddd.DomainEvent) error {
+	h.events = append(h.events, event)
+	return nil
+}
+
+func TestSubscribeDomainEventHandlers(t *testing.T) {
+	t.Run("order status events reach the handler through the outbox", func(t *testing.T) {
+		registry, err := outbox.NewEventRegistry()
+		require.NoError(t, err)
+		require.NoError(t, registerDomainEvents(registry))
+		mediatr := ddd.NewMediatr()
+		handler := &recordingEventHandler{}
+
+		require.NoError(t, subscribeDomainEventHandlers(mediatr, registry, handler))
+
+		orderID := uuid.New()
+		events := []ddd.DomainEvent{
+			&order.OrderCreatedDomainEvent{ID: uuid.New(), OrderID: orderID, OrderStatus: "Created"},
+			&order.OrderAssignedDomainEvent{ID: uuid.New(), OrderID: orderID, OrderStatus: "Assigned"},
+			&order.OrderCompletedDomainEvent{ID: uuid.New(), OrderID: orderID, OrderStatus: "Completed"},
+			&order.OrderReleasedDomainEvent{ID: uuid.New(), OrderID: orderID, OrderStatus: "Created", CourierID: uuid.New()},
+		}
+		for _, event := range events {
+			message, err := outbox.EncodeDomainEvent(event, clock.System())
+			require.NoError(t, err)
+			decoded, err := registry.DecodeDomainEvent(&message)
+			require.NoError(t, err)
+			require.NoError(t, mediatr.Publish(context.Background(), decoded))
+		}
+
+		assert.Equal(t, events, handler.events)
+	})
+
+	t.Run("unregistered event", func(t *testing.T) {
+		registry, err := outbox.NewEventRegistry()
+		require.NoError(t, err)
+
+		err = subscribeDomainEventHandlers(ddd.NewMediatr(), registry, &recordingEventHandler{})
+
+		assert.ErrorContains(t, err, "OrderCreatedDomainEvent")
+	})
+}
+
+func TestNewRandomSource_ReproducibleIds(t *testing.T) {
+	t.Cleanup(func() { uuid.SetRand(nil) })
+	seed := int64(7)
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 1, 1)
+	require.NoError(t, err)
+
+	ids := func() []uuid.UUID {
+		uuid.SetRand(newRandomSource(&seed))
+		aggregate, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, location)
+		require.NoError(t, err)
+		ids := []uuid.UUID{aggregate.ID()}
+		for _, event := range aggregate.GetDomainEvents() {
+			ids = append(ids, event.GetID())
+		}
+		return ids
+	}
+
+	assert.Equal(t, ids(), ids())
+}
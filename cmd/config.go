@@ -1,9 +1,52 @@
 package cmd
 
+import (
+	"delivery/internal/pkg/logging"
+	"delivery/internal/pkg/tracing"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile читается, только если существует: в контейнерах настройки
+// обычно приходят переменными окружения
+const defaultConfigFile = ".env"
+
+// configFileKey задает путь к файлу настроек (.env или YAML)
+const configFileKey = "CONFIG_FILE"
+
+var (
+	ErrInvalidConfig = errors.New("invalid config")
+
+	topicPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+	sslModes     = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+)
+
+// Способ измерять расстояние между точками города
+const (
+	DistanceModeGrid = "grid"
+	DistanceModeGeo  = "geo"
+)
+
+var distanceModes = []string{DistanceModeGrid, DistanceModeGeo}
+
 type Config struct {
-	HttpPort                  string
+	HttpPort                  int
 	DbHost                    string
-	DbPort                    string
+	DbPort                    int
 	DbUser                    string
 	DbPassword                string
 	DbName                    string
@@ -12,5 +55,453 @@ type Config struct {
 	KafkaHost                 string
 	KafkaConsumerGroup        string
 	KafkaBasketConfirmedTopic string
+	KafkaBasketConfirmedDLQ   string
 	KafkaOrderChangedTopic    string
+	AssignOrdersInterval      time.Duration
+	MoveCouriersInterval      time.Duration
+	OutboxRelayInterval       time.Duration
+	OutboxRelayBatchSize      int
+	OutboxMaxAttempts         int
+	TracingExporter           string
+	TracingOtlpEndpoint       string
+	LogLevel                  string
+	LogFormat                 string
+	GridWidth                 int
+	GridHeight                int
+	DistanceMode              string
+	MapOriginLatitude         float64
+	MapOriginLongitude        float64
+	MapCellSize               float64
+	RandomSeed                *int64
+	MapBlockedCells           []CellArea
+	TrafficRefreshInterval    time.Duration
+}
+
+// KafkaBrokers возвращает адреса брокеров из KafkaHost, перечисленные через запятую
+func (c Config) KafkaBrokers() []string {
+	var brokers []string
+	for _, broker := range strings.Split(c.KafkaHost, ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	return brokers
+}
+
+// CellArea — прямоугольник клеток сетки, включая границы
+type CellArea struct {
+	MinX int
+	MinY int
+	MaxX int
+	MaxY int
+}
+
+// configField описывает один параметр: ключ в файле и окружении, значение по
+// умолчанию и разбор строки в поле Config. Флаг командной строки получается из
+// ключа: HTTP_PORT -> --http-port. Пустое значение допустимо только для
+// optional-параметров.
+type configField struct {
+	key          string
+	defaultValue string
+	usage        string
+	optional     bool
+	set          func(c *Config, value string) error
+}
+
+var configFields = []configField{
+	{key: "HTTP_PORT", defaultValue: "8082", usage: "HTTP server port", set: func(c *Config, v string) (err error) {
+		c.HttpPort, err = parsePort(v)
+		return err
+	}},
+	{key: "DB_HOST", defaultValue: "localhost", usage: "Postgres host", set: func(c *Config, v string) error {
+		c.DbHost = v
+		return nil
+	}},
+	{key: "DB_PORT", defaultValue: "5432", usage: "Postgres port", set: func(c *Config, v string) (err error) {
+		c.DbPort, err = parsePort(v)
+		return err
+	}},
+	{key: "DB_USER", usage: "Postgres user", set: func(c *Config, v string) error {
+		c.DbUser = v
+		return nil
+	}},
+	{key: "DB_PASSWORD", usage: "Postgres password", set: func(c *Config, v string) error {
+		c.DbPassword = v
+		return nil
+	}},
+	{key: "DB_NAME", usage: "Postgres database", set: func(c *Config, v string) error {
+		c.DbName = v
+		return nil
+	}},
+	{key: "DB_SSLMODE", defaultValue: "disable", usage: "Postgres sslmode", set: func(c *Config, v string) error {
+		c.DbSslMode = v
+		return nil
+	}},
+	{key: "GEO_SERVICE_GRPC_HOST", defaultValue: "localhost:5004", usage: "Geo service gRPC address", set: func(c *Config, v string) error {
+		c.GeoServiceGrpcHost = v
+		return nil
+	}},
+	{key: "KAFKA_HOST", defaultValue: "localhost:9092", usage: "Kafka brokers, comma separated", set: func(c *Config, v string) error {
+		c.KafkaHost = v
+		return nil
+	}},
+	{key: "KAFKA_CONSUMER_GROUP", defaultValue: "delivery-service-group", usage: "Kafka consumer group", set: func(c *Config, v string) error {
+		c.KafkaConsumerGroup = v
+		return nil
+	}},
+	{key: "KAFKA_BASKET_CONFIRMED_TOPIC", defaultValue: "basket.confirmed", usage: "basket confirmed topic", set: func(c *Config, v string) error {
+		c.KafkaBasketConfirmedTopic = v
+		return nil
+	}},
+	{key: "KAFKA_BASKET_CONFIRMED_DLQ_TOPIC", defaultValue: "basket.confirmed.dlq", usage: "dead letter topic for basket confirmed messages", set: func(c *Config, v string) error {
+		c.KafkaBasketConfirmedDLQ = v
+		return nil
+	}},
+	{key: "KAFKA_ORDER_CHANGED_TOPIC", defaultValue: "order.status.changed", usage: "order status changed topic", set: func(c *Config, v string) error {
+		c.KafkaOrderChangedTopic = v
+		return nil
+	}},
+	{key: "ASSIGN_ORDERS_INTERVAL", defaultValue: "1s", usage: "assign orders job interval", set: func(c *Config, v string) (err error) {
+		c.AssignOrdersInterval, err = parseInterval(v)
+		return err
+	}},
+	{key: "MOVE_COURIERS_INTERVAL", defaultValue: "2s", usage: "move couriers job interval", set: func(c *Config, v string) (err error) {
+		c.MoveCouriersInterval, err = parseInterval(v)
+		return err
+	}},
+	{key: "OUTBOX_RELAY_INTERVAL", defaultValue: "1s", usage: "outbox relay job interval", set: func(c *Config, v string) (err error) {
+		c.OutboxRelayInterval, err = parseInterval(v)
+		return err
+	}},
+	{key: "OUTBOX_RELAY_BATCH_SIZE", defaultValue: "100", usage: "outbox messages published per tick", set: func(c *Config, v string) (err error) {
+		c.OutboxRelayBatchSize, err = parsePositiveInt(v)
+		return err
+	}},
+	{key: "OUTBOX_MAX_ATTEMPTS", defaultValue: "10", usage: "publication attempts before an outbox message is quarantined", set: func(c *Config, v string) (err error) {
+		c.OutboxMaxAttempts, err = parsePositiveInt(v)
+		return err
+	}},
+	{key: "TRACING_EXPORTER", defaultValue: tracing.ExporterNone, usage: "span exporter: none, stdout or otlp", set: func(c *Config, v string) error {
+		if !slices.Contains(tracing.Exporters, v) {
+			return fmt.Errorf("must be one of %s", strings.Join(tracing.Exporters, ", "))
+		}
+		c.TracingExporter = v
+		return nil
+	}},
+	{key: "TRACING_OTLP_ENDPOINT", defaultValue: "localhost:4317", usage: "OTLP gRPC collector address", set: func(c *Config, v string) error {
+		c.TracingOtlpEndpoint = v
+		return nil
+	}},
+	{key: "LOG_LEVEL", defaultValue: "info", usage: "log level: debug, info, warn or error", set: func(c *Config, v string) error {
+		if !slices.Contains(logging.Levels, strings.ToLower(v)) {
+			return fmt.Errorf("must be one of %s", strings.Join(logging.Levels, ", "))
+		}
+		c.LogLevel = strings.ToLower(v)
+		return nil
+	}},
+	{key: "LOG_FORMAT", defaultValue: logging.FormatJson, usage: "log format: json or text", set: func(c *Config, v string) error {
+		if !slices.Contains(logging.Formats, v) {
+			return fmt.Errorf("must be one of %s", strings.Join(logging.Formats, ", "))
+		}
+		c.LogFormat = v
+		return nil
+	}},
+	{key: "GRID_WIDTH", defaultValue: "10", usage: "city grid width", set: func(c *Config, v string) (err error) {
+		c.GridWidth, err = parsePositiveInt(v)
+		return err
+	}},
+	{key: "GRID_HEIGHT", defaultValue: "10", usage: "city grid height", set: func(c *Config, v string) (err error) {
+		c.GridHeight, err = parsePositiveInt(v)
+		return err
+	}},
+	{key: "DISTANCE_MODE", defaultValue: DistanceModeGrid, usage: "distance between locations: grid (route) or geo (straight-line estimate)", set: func(c *Config, v string) error {
+		if !slices.Contains(distanceModes, v) {
+			return fmt.Errorf("must be one of %s", strings.Join(distanceModes, ", "))
+		}
+		c.DistanceMode = v
+		return nil
+	}},
+	{key: "MAP_ORIGIN", defaultValue: "55.7558,37.6173", usage: "latitude,longitude of grid cell (1, 1) in geo mode", set: func(c *Config, v string) (err error) {
+		c.MapOriginLatitude, c.MapOriginLongitude, err = parseCoordinates(v)
+		return err
+	}},
+	{key: "MAP_CELL_SIZE", defaultValue: "100", usage: "grid cell size in meters in geo mode", set: func(c *Config, v string) (err error) {
+		c.MapCellSize, err = parsePositiveFloat(v)
+		return err
+	}},
+	{key: "RANDOM_SEED", optional: true, usage: "seed for reproducible runs, empty for a random one", set: func(c *Config, v string) error {
+		seed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", v)
+		}
+		c.RandomSeed = &seed
+		return nil
+	}},
+	{key: "MAP_BLOCKED_CELLS", optional: true, usage: "impassable cells separated by ';': x,y or x1,y1-x2,y2", set: func(c *Config, v string) (err error) {
+		c.MapBlockedCells, err = parseCellAreas(v)
+		return err
+	}},
+	{key: "TRAFFIC_REFRESH_INTERVAL", defaultValue: "10s", usage: "how often traffic zones are reloaded from the database", set: func(c *Config, v string) (err error) {
+		c.TrafficRefreshInterval, err = parseInterval(v)
+		return err
+	}},
+}
+
+// LoadConfig собирает настройки по слоям, каждый следующий перекрывает
+// предыдущий: значения по умолчанию, файл (.env или YAML), переменные
+// окружения, флаги командной строки. Возвращает аргументы, оставшиеся после
+// флагов, и все найденные ошибки сразу.
+func LoadConfig(args []string, lookupEnv func(string) (string, bool)) (Config, []string, error) {
+	values := make(map[string]string, len(configFields))
+	for _, field := range configFields {
+		if field.defaultValue != "" {
+			values[field.key] = field.defaultValue
+		}
+	}
+
+	flags := flag.NewFlagSet("delivery", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	configFile := flags.String(flagName(configFileKey), "", "path to .env or YAML config file")
+	flagValues := make(map[string]*string, len(configFields))
+	for _, field := range configFields {
+		flagValues[field.key] = flags.String(flagName(field.key), "", field.usage)
+	}
+	if err := flags.Parse(args); err != nil {
+		return Config{}, nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	path, explicit := *configFile, *configFile != ""
+	if !explicit {
+		path, explicit = lookupEnv(configFileKey)
+	}
+	if !explicit {
+		path = defaultConfigFile
+	}
+	fileValues, err := readConfigFile(path, explicit)
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	for key, value := range fileValues {
+		values[key] = value
+	}
+
+	for _, field := range configFields {
+		if value, ok := lookupEnv(field.key); ok {
+			values[field.key] = value
+		}
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		for _, field := range configFields {
+			if flagName(field.key) == f.Name {
+				values[field.key] = *flagValues[field.key]
+			}
+		}
+	})
+
+	var config Config
+	var problems []error
+	for _, field := range configFields {
+		value := strings.TrimSpace(values[field.key])
+		if value == "" && field.optional {
+			continue
+		}
+		if value == "" {
+			problems = append(problems, fmt.Errorf("%s: value is required", field.key))
+			continue
+		}
+		if err := field.set(&config, value); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", field.key, err))
+		}
+	}
+	problems = append(problems, config.validate()...)
+	if len(problems) > 0 {
+		return Config{}, nil, fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(problems...))
+	}
+
+	return config, flags.Args(), nil
+}
+
+func (c Config) validate() []error {
+	var problems []error
+	if c.DbSslMode != "" && !slices.Contains(sslModes, c.DbSslMode) {
+		problems = append(problems, fmt.Errorf("DB_SSLMODE: must be one of %s", strings.Join(sslModes, ", ")))
+	}
+	if c.TracingExporter == tracing.ExporterOtlp && c.TracingOtlpEndpoint != "" {
+		if err := validateHostPort(c.TracingOtlpEndpoint); err != nil {
+			problems = append(problems, fmt.Errorf("TRACING_OTLP_ENDPOINT: %w", err))
+		}
+	}
+	if c.GeoServiceGrpcHost != "" {
+		if err := validateHostPort(c.GeoServiceGrpcHost); err != nil {
+			problems = append(problems, fmt.Errorf("GEO_SERVICE_GRPC_HOST: %w", err))
+		}
+	}
+
+	for _, broker := range c.KafkaBrokers() {
+		if err := validateHostPort(broker); err != nil {
+			problems = append(problems, fmt.Errorf("KAFKA_HOST: %w", err))
+		}
+	}
+
+	if c.KafkaBasketConfirmedTopic != "" && !topicPattern.MatchString(c.KafkaBasketConfirmedTopic) {
+		problems = append(problems, fmt.Errorf("KAFKA_BASKET_CONFIRMED_TOPIC: %q is not a valid topic name", c.KafkaBasketConfirmedTopic))
+	}
+	if c.KafkaBasketConfirmedDLQ != "" && !topicPattern.MatchString(c.KafkaBasketConfirmedDLQ) {
+		problems = append(problems, fmt.Errorf("KAFKA_BASKET_CONFIRMED_DLQ_TOPIC: %q is not a valid topic name", c.KafkaBasketConfirmedDLQ))
+	}
+	if c.KafkaBasketConfirmedDLQ != "" && c.KafkaBasketConfirmedDLQ == c.KafkaBasketConfirmedTopic {
+		problems = append(problems, fmt.Errorf("KAFKA_BASKET_CONFIRMED_DLQ_TOPIC: must differ from KAFKA_BASKET_CONFIRMED_TOPIC"))
+	}
+	if c.KafkaOrderChangedTopic != "" && !topicPattern.MatchString(c.KafkaOrderChangedTopic) {
+		problems = append(problems, fmt.Errorf("KAFKA_ORDER_CHANGED_TOPIC: %q is not a valid topic name", c.KafkaOrderChangedTopic))
+	}
+	for _, area := range c.MapBlockedCells {
+		if c.GridWidth > 0 && c.GridHeight > 0 && (area.MaxX > c.GridWidth || area.MaxY > c.GridHeight) {
+			problems = append(problems, fmt.Errorf("MAP_BLOCKED_CELLS: %d,%d is outside the %dx%d grid", area.MaxX, area.MaxY, c.GridWidth, c.GridHeight))
+		}
+	}
+	return problems
+}
+
+// readConfigFile читает .env или YAML (по расширению). Отсутствие файла — не
+// ошибка, если путь не задан явно.
+func readConfigFile(path string, explicit bool) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var document map[string]any
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+		}
+		values := make(map[string]string, len(document))
+		for key, value := range document {
+			values[strings.ToUpper(key)] = fmt.Sprint(value)
+		}
+		return values, nil
+	default:
+		values, err := godotenv.UnmarshalBytes(content)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+		}
+		return values, nil
+	}
+}
+
+func flagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("%q is not a valid port", value)
+	}
+	return port, nil
+}
+
+func parsePositiveInt(value string) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("%q is not a positive integer", value)
+	}
+	return number, nil
+}
+
+func parsePositiveFloat(value string) (float64, error) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(number, 0) || !(number > 0) {
+		return 0, fmt.Errorf("%q is not a positive number", value)
+	}
+	return number, nil
+}
+
+// parseCoordinates разбирает "широта,долгота" в градусах
+func parseCoordinates(value string) (float64, float64, error) {
+	latitudeValue, longitudeValue, ok := strings.Cut(value, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q is not a latitude,longitude pair", value)
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(latitudeValue), 64)
+	if err != nil || latitude <= -90 || latitude >= 90 {
+		return 0, 0, fmt.Errorf("%q is not a valid latitude", latitudeValue)
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(longitudeValue), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return 0, 0, fmt.Errorf("%q is not a valid longitude", longitudeValue)
+	}
+	return latitude, longitude, nil
+}
+
+// parseCellAreas разбирает "5,1-5,8;7,3": отдельные клетки и прямоугольники
+func parseCellAreas(value string) ([]CellArea, error) {
+	var areas []CellArea
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		from, to, isArea := strings.Cut(item, "-")
+		minX, minY, err := parseCell(from)
+		if err != nil {
+			return nil, err
+		}
+		maxX, maxY := minX, minY
+		if isArea {
+			if maxX, maxY, err = parseCell(to); err != nil {
+				return nil, err
+			}
+		}
+
+		areas = append(areas, CellArea{
+			MinX: min(minX, maxX),
+			MinY: min(minY, maxY),
+			MaxX: max(minX, maxX),
+			MaxY: max(minY, maxY),
+		})
+	}
+	return areas, nil
+}
+
+func parseCell(value string) (int, int, error) {
+	xValue, yValue, ok := strings.Cut(value, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q is not an x,y cell", value)
+	}
+	x, err := parsePositiveInt(strings.TrimSpace(xValue))
+	if err != nil {
+		return 0, 0, err
+	}
+	y, err := parsePositiveInt(strings.TrimSpace(yValue))
+	if err != nil {
+		return 0, 0, err
+	}
+	return x, y, nil
+}
+
+func parseInterval(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("%q is not a positive duration", value)
+	}
+	return duration, nil
+}
+
+func validateHostPort(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || host == "" {
+		return fmt.Errorf("%q is not a host:port address", address)
+	}
+	if _, err := parsePort(port); err != nil {
+		return err
+	}
+	return nil
 }
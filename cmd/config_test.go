@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envFrom(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func requiredEnv() map[string]string {
+	return map[string]string{
+		"DB_USER":     "username",
+		"DB_PASSWORD": "secret",
+		"DB_NAME":     "delivery",
+	}
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	config, args, err := LoadConfig(nil, envFrom(requiredEnv()))
+
+	require.NoError(t, err)
+	assert.Empty(t, args)
+	assert.Equal(t, 8082, config.HttpPort)
+	assert.Equal(t, "localhost", config.DbHost)
+	assert.Equal(t, 5432, config.DbPort)
+	assert.Equal(t, "disable", config.DbSslMode)
+	assert.Equal(t, []string{"localhost:9092"}, config.KafkaBrokers())
+	assert.Equal(t, time.Second, config.AssignOrdersInterval)
+	assert.Equal(t, 2*time.Second, config.MoveCouriersInterval)
+	assert.Equal(t, time.Second, config.OutboxRelayInterval)
+	assert.Equal(t, 100, config.OutboxRelayBatchSize)
+	assert.Equal(t, "none", config.TracingExporter)
+	assert.Equal(t, "info", config.LogLevel)
+	assert.Equal(t, "json", config.LogFormat)
+	assert.Equal(t, 10, config.GridWidth)
+	assert.Equal(t, 10, config.GridHeight)
+	assert.Equal(t, DistanceModeGrid, config.DistanceMode)
+	assert.Equal(t, 100.0, config.MapCellSize)
+	assert.Nil(t, config.RandomSeed)
+	assert.Empty(t, config.MapBlockedCells)
+	assert.Equal(t, 10*time.Second, config.TrafficRefreshInterval)
+}
+
+func TestLoadConfig_MapBlockedCells(t *testing.T) {
+	t.Chdir(t.TempDir())
+	env := requiredEnv()
+	env["MAP_BLOCKED_CELLS"] = "5,1-5,8; 7,3; 9,9-8,6"
+
+	config, _, err := LoadConfig(nil, envFrom(env))
+
+	require.NoError(t, err)
+	assert.Equal(t, []CellArea{
+		{MinX: 5, MinY: 1, MaxX: 5, MaxY: 8},
+		{MinX: 7, MinY: 3, MaxX: 7, MaxY: 3},
+		{MinX: 8, MinY: 6, MaxX: 9, MaxY: 9},
+	}, config.MapBlockedCells)
+
+	env["MAP_BLOCKED_CELLS"] = "5,1-11,1"
+	_, _, err = LoadConfig(nil, envFrom(env))
+	assert.ErrorContains(t, err, "MAP_BLOCKED_CELLS")
+
+	env["MAP_BLOCKED_CELLS"] = "5;1"
+	_, _, err = LoadConfig(nil, envFrom(env))
+	assert.ErrorContains(t, err, "MAP_BLOCKED_CELLS")
+}
+
+func TestLoadConfig_GeoDistance(t *testing.T) {
+	t.Chdir(t.TempDir())
+	env := requiredEnv()
+	env["DISTANCE_MODE"] = "geo"
+	env["MAP_ORIGIN"] = "59.9386, 30.3141"
+	env["MAP_CELL_SIZE"] = "250.5"
+
+	config, _, err := LoadConfig(nil, envFrom(env))
+
+	require.NoError(t, err)
+	assert.Equal(t, DistanceModeGeo, config.DistanceMode)
+	assert.Equal(t, 59.9386, config.MapOriginLatitude)
+	assert.Equal(t, 30.3141, config.MapOriginLongitude)
+	assert.Equal(t, 250.5, config.MapCellSize)
+}
+
+func TestLoadConfig_ZeroRandomSeed(t *testing.T) {
+	t.Chdir(t.TempDir())
+	env := requiredEnv()
+	env["RANDOM_SEED"] = "0"
+
+	config, _, err := LoadConfig(nil, envFrom(env))
+
+	require.NoError(t, err)
+	require.NotNil(t, config.RandomSeed)
+	assert.Equal(t, int64(0), *config.RandomSeed)
+}
+
+func TestLoadConfig_LayersOverrideEachOther(t *testing.T) {
+	path := writeFile(t, "delivery.env", `
+HTTP_PORT="9000"
+DB_HOST="file-host"
+DB_USER="file-user"
+DB_PASSWORD="file-secret"
+DB_NAME="file-db"
+MOVE_COURIERS_INTERVAL="5s"
+`)
+	env := map[string]string{
+		"CONFIG_FILE": path,
+		"DB_HOST":     "env-host",
+		"HTTP_PORT":   "9100",
+	}
+
+	config, args, err := LoadConfig([]string{"--http-port", "9200", "migrate", "up"}, envFrom(env))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"migrate", "up"}, args)
+	assert.Equal(t, 9200, config.HttpPort)
+	assert.Equal(t, "env-host", config.DbHost)
+	assert.Equal(t, "file-user", config.DbUser)
+	assert.Equal(t, 5*time.Second, config.MoveCouriersInterval)
+}
+
+// TestRepoDotEnv охраняет формат .env из корня репозитория: по ключу на
+// строку, перевод строки в конце и все ключи из configFields
+func TestRepoDotEnv(t *testing.T) {
+	path := filepath.Join("..", ".env")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasSuffix(string(content), "\n"), ".env must end with a newline")
+
+	linePattern := regexp.MustCompile(`^[A-Z][A-Z0-9_]*="[^"]*"$`)
+	var keys []string
+	for _, line := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+		require.Regexp(t, linePattern, line)
+		keys = append(keys, strings.SplitN(line, "=", 2)[0])
+	}
+
+	var expected []string
+	for _, field := range configFields {
+		expected = append(expected, field.key)
+	}
+	assert.ElementsMatch(t, expected, keys)
+
+	_, _, err = LoadConfig(nil, envFrom(map[string]string{"CONFIG_FILE": path}))
+	assert.NoError(t, err)
+}
+
+func TestLoadConfig_YamlFile(t *testing.T) {
+	path := writeFile(t, "delivery.yaml", `
+db_user: username
+db_password: secret
+db_name: delivery
+kafka_host: kafka-1:9092, kafka-2:9092
+outbox_relay_batch_size: 20
+`)
+
+	config, _, err := LoadConfig([]string{"--config-file", path}, envFrom(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, "username", config.DbUser)
+	assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, config.KafkaBrokers())
+	assert.Equal(t, 20, config.OutboxRelayBatchSize)
+}
+
+func TestLoadConfig_MissingExplicitFile(t *testing.T) {
+	_, _, err := LoadConfig(nil, envFrom(map[string]string{"CONFIG_FILE": "/does/not/exist.env"}))
+
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestLoadConfig_ReportsAllErrors(t *testing.T) {
+	t.Chdir(t.TempDir())
+	env := map[string]string{
+		"HTTP_PORT":                    "http",
+		"DB_PORT":                      "70000",
+		"DB_SSLMODE":                   "sometimes",
+		"GEO_SERVICE_GRPC_HOST":        "geo",
+		"KAFKA_HOST":                   "kafka:9092,broker",
+		"KAFKA_BASKET_CONFIRMED_TOPIC": "basket confirmed",
+		"ASSIGN_ORDERS_INTERVAL":       "-1s",
+		"OUTBOX_RELAY_BATCH_SIZE":      "0",
+		"TRACING_EXPORTER":             "jaeger",
+		"LOG_LEVEL":                    "verbose",
+		"DISTANCE_MODE":                "sphere",
+		"MAP_ORIGIN":                   "91,37",
+		"MAP_CELL_SIZE":                "-5",
+		"RANDOM_SEED":                  "seed",
+	}
+
+	_, _, err := LoadConfig(nil, envFrom(env))
+
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	for _, key := range []string{
+		"HTTP_PORT", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_SSLMODE",
+		"GEO_SERVICE_GRPC_HOST", "KAFKA_HOST", "KAFKA_BASKET_CONFIRMED_TOPIC",
+		"ASSIGN_ORDERS_INTERVAL", "OUTBOX_RELAY_BATCH_SIZE", "TRACING_EXPORTER", "LOG_LEVEL",
+		"DISTANCE_MODE", "MAP_ORIGIN", "MAP_CELL_SIZE", "RANDOM_SEED",
+	} {
+		assert.ErrorContains(t, err, key)
+	}
+}
+
+func TestLoadConfig_UnknownFlag(t *testing.T) {
+	_, _, err := LoadConfig([]string{"--unknown"}, envFrom(requiredEnv()))
+
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
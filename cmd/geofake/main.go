@@ -0,0 +1,33 @@
+package main
+
+import (
+	"delivery/internal/adapters/out/grpc/geo/geofake"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Локальная замена сервиса Geo: go run ./cmd/geofake -addr 0.0.0.0:5004 -width 200 -height 150
+func main() {
+	addr := flag.String("addr", "0.0.0.0:5004", "address to listen on")
+	width := flag.Uint("width", 10, "grid width, must match GRID_WIDTH of the delivery service")
+	height := flag.Uint("height", 10, "grid height, must match GRID_HEIGHT of the delivery service")
+	flag.Parse()
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("cannot listen on %s: %v", *addr, err)
+	}
+
+	grpcServer := geofake.NewServer(geofake.WithGrid(uint32(*width), uint32(*height))).Serve(listener)
+	log.Printf("fake geo service is listening on %s", listener.Addr())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+
+	grpcServer.GracefulStop()
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"net/http"
+)
+
+func (s *Server) CreateCourier(ctx context.Context, request servers.CreateCourierRequestObject) (servers.CreateCourierResponseObject, error) {
+	if request.Body == nil {
+		return servers.CreateCourier400JSONResponse(newError(http.StatusBadRequest, errs.NewValueIsRequiredError("body"))), nil
+	}
+
+	transport, err := kernel.ParseTransport(string(request.Body.Transport))
+	if err != nil {
+		return servers.CreateCourier400JSONResponse(newError(http.StatusBadRequest, err)), nil
+	}
+
+	command, err := commands.NewCreateCourierCommand(request.Body.Name, transport, request.Body.Speed)
+	if err != nil {
+		return servers.CreateCourier400JSONResponse(newError(http.StatusBadRequest, err)), nil
+	}
+
+	if err := s.createCourierCommandHandler.Handle(ctx, command); err != nil {
+		if isValidationError(err) {
+			return servers.CreateCourier400JSONResponse(newError(http.StatusBadRequest, err)), nil
+		}
+		return servers.CreateCourierdefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	return servers.CreateCourier201Response{}, nil
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) CreateOrder(ctx context.Context, request servers.CreateOrderRequestObject) (servers.CreateOrderResponseObject, error) {
+	if request.Body == nil {
+		return servers.CreateOrder400JSONResponse(newError(http.StatusBadRequest, errs.NewValueIsRequiredError("body"))), nil
+	}
+
+	orderID := uuid.New()
+	if request.Body.Id != nil {
+		orderID = *request.Body.Id
+	}
+
+	command, err := commands.NewCreateOrderCommand(orderID, request.Body.Street, request.Body.Volume)
+	if err != nil {
+		return servers.CreateOrder400JSONResponse(newError(http.StatusBadRequest, err)), nil
+	}
+
+	if err := s.createOrderCommandHandler.Handle(ctx, command); err != nil {
+		if isValidationError(err) {
+			return servers.CreateOrder400JSONResponse(newError(http.StatusBadRequest, err)), nil
+		}
+		return servers.CreateOrderdefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	return servers.CreateOrder201Response{}, nil
+}
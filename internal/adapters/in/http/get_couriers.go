@@ -0,0 +1,41 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/generated/servers"
+	"net/http"
+)
+
+func (s *Server) GetCouriers(ctx context.Context, _ servers.GetCouriersRequestObject) (servers.GetCouriersResponseObject, error) {
+	query, err := queries.NewGetAllCouriersQuery()
+	if err != nil {
+		return servers.GetCouriersdefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	response, err := s.getAllCouriersQueryHandler.Handle(ctx, query)
+	if err != nil {
+		return servers.GetCouriersdefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	couriers := make(servers.GetCouriers200JSONResponse, 0, len(response.Couriers))
+	for _, courier := range response.Couriers {
+		couriers = append(couriers, servers.Courier{
+			Id:        courier.ID,
+			Name:      courier.Name,
+			Transport: servers.Transport(courier.Transport),
+			Location: servers.Location{
+				X: courier.Location.X,
+				Y: courier.Location.Y,
+			},
+		})
+	}
+
+	return couriers, nil
+}
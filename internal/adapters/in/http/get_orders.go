@@ -0,0 +1,39 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/generated/servers"
+	"net/http"
+)
+
+func (s *Server) GetOrders(ctx context.Context, _ servers.GetOrdersRequestObject) (servers.GetOrdersResponseObject, error) {
+	query, err := queries.NewGetNotCompletedOrdersQuery()
+	if err != nil {
+		return servers.GetOrdersdefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	response, err := s.getNotCompletedOrdersQueryHandler.Handle(ctx, query)
+	if err != nil {
+		return servers.GetOrdersdefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	orders := make(servers.GetOrders200JSONResponse, 0, len(response.Orders))
+	for _, order := range response.Orders {
+		orders = append(orders, servers.Order{
+			Id: order.ID,
+			Location: servers.Location{
+				X: order.Location.X,
+				Y: order.Location.Y,
+			},
+		})
+	}
+
+	return orders, nil
+}
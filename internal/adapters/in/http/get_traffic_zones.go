@@ -0,0 +1,38 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/generated/servers"
+	"net/http"
+)
+
+func (s *Server) GetTrafficZones(ctx context.Context, _ servers.GetTrafficZonesRequestObject) (servers.GetTrafficZonesResponseObject, error) {
+	query, err := queries.NewGetTrafficZonesQuery()
+	if err != nil {
+		return servers.GetTrafficZonesdefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	response, err := s.getTrafficZonesQueryHandler.Handle(ctx, query)
+	if err != nil {
+		return servers.GetTrafficZonesdefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	zones := make(servers.GetTrafficZones200JSONResponse, 0, len(response.TrafficZones))
+	for _, zone := range response.TrafficZones {
+		zones = append(zones, servers.TrafficZone{
+			Name:        zone.Name,
+			From:        servers.Location{X: zone.From.X, Y: zone.From.Y},
+			To:          servers.Location{X: zone.To.X, Y: zone.To.Y},
+			Multipliers: zone.Multipliers,
+		})
+	}
+
+	return zones, nil
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/health"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HealthHandler обслуживает пробы Kubernetes. Liveness не трогает зависимости:
+// недоступная БД не повод перезапускать процесс. Readiness возвращает 503 только
+// при отказе обязательной зависимости, чтобы трафик не шел на неготовый
+// экземпляр; отказ необязательной виден в отчете со статусом degraded.
+type HealthHandler struct {
+	registry *health.Registry
+}
+
+func NewHealthHandler(registry *health.Registry) (*HealthHandler, error) {
+	if registry == nil {
+		return nil, errs.NewValueIsRequiredError("registry")
+	}
+
+	return &HealthHandler{
+		registry: registry,
+	}, nil
+}
+
+func (h *HealthHandler) Register(e *echo.Echo) {
+	e.GET("/health/live", h.Live)
+	e.GET("/health/ready", h.Ready)
+	// Старый адрес оставлен для совместимости и отвечает как readiness
+	e.GET("/health", h.Ready)
+}
+
+func (h *HealthHandler) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, health.Report{Status: health.StatusUp, Checks: map[string]health.CheckResult{}})
+}
+
+func (h *HealthHandler) Ready(c echo.Context) error {
+	report := h.registry.Check(c.Request().Context())
+
+	status := http.StatusOK
+	if report.Status == health.StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	return c.JSON(status, report)
+}
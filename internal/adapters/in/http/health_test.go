@@ -0,0 +1,97 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/pkg/health"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHealthEcho(t *testing.T, checkErr error, optionalErr ...error) *echo.Echo {
+	t.Helper()
+
+	registry, err := health.NewRegistry(time.Second)
+	require.NoError(t, err)
+	require.NoError(t, registry.Register("postgres", health.CheckerFunc(func(context.Context) (map[string]any, error) {
+		return map[string]any{"openConnections": 1}, checkErr
+	})))
+	for _, err := range optionalErr {
+		require.NoError(t, registry.RegisterOptional("kafka", health.CheckerFunc(func(context.Context) (map[string]any, error) {
+			return nil, err
+		})))
+	}
+
+	handler, err := NewHealthHandler(registry)
+	require.NoError(t, err)
+
+	e := echo.New()
+	handler.Register(e)
+	return e
+}
+
+func TestNewHealthHandler(t *testing.T) {
+	_, err := NewHealthHandler(nil)
+	assert.Error(t, err)
+}
+
+func TestHealthHandler_Live(t *testing.T) {
+	e := newHealthEcho(t, errors.New("db is down"))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"up","checks":{}}`, rec.Body.String())
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	t.Run("dependencies up", func(t *testing.T) {
+		e := newHealthEcho(t, nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var report health.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, health.StatusUp, report.Status)
+		assert.Equal(t, health.StatusUp, report.Checks["postgres"].Status)
+		assert.InDelta(t, 1, report.Checks["postgres"].Details["openConnections"], 0)
+	})
+
+	t.Run("optional dependency down", func(t *testing.T) {
+		e := newHealthEcho(t, nil, errors.New("no brokers"))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var report health.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, health.StatusDegraded, report.Status)
+		assert.Equal(t, "no brokers", report.Checks["kafka"].Error)
+	})
+
+	t.Run("dependency down", func(t *testing.T) {
+		e := newHealthEcho(t, errors.New("db is down"))
+
+		for _, path := range []string{"/health/ready", "/health"} {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+			var report health.Report
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			assert.Equal(t, health.StatusDown, report.Status)
+			assert.Equal(t, "db is down", report.Checks["postgres"].Error)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"delivery/internal/pkg/correlation"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// CorrelationMiddleware присваивает запросу request ID и correlation ID. Оба
+// принимаются из заголовков, если их передал вызывающий сервис, иначе request
+// ID генерируется, а цепочка начинается с него. Идентификаторы кладутся в
+// контекст запроса и возвращаются в заголовках ответа.
+func CorrelationMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+
+			requestID := request.Header.Get(correlation.RequestIDHeader)
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+			correlationID := request.Header.Get(correlation.CorrelationIDHeader)
+			if correlationID == "" {
+				correlationID = requestID
+			}
+
+			ctx := correlation.WithRequestID(request.Context(), requestID)
+			ctx = correlation.WithCorrelationID(ctx, correlationID)
+			c.SetRequest(request.WithContext(ctx))
+
+			c.Response().Header().Set(correlation.RequestIDHeader, requestID)
+			c.Response().Header().Set(correlation.CorrelationIDHeader, correlationID)
+			return next(c)
+		}
+	}
+}
+
+// RequestLogger пишет одну запись на запрос. Ошибки обработчиков сразу
+// передаются в обработчик ошибок Echo, чтобы в лог попал итоговый статус.
+func RequestLogger(logger *slog.Logger) echo.MiddlewareFunc {
+	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogMethod:    true,
+		LogURI:       true,
+		LogRoutePath: true,
+		LogStatus:    true,
+		LogLatency:   true,
+		LogError:     true,
+		HandleError:  true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			attrs := []slog.Attr{
+				slog.String("method", v.Method),
+				slog.String("uri", v.URI),
+				slog.String("route", v.RoutePath),
+				slog.Int("status", v.Status),
+				slog.Duration("latency", v.Latency),
+			}
+
+			level := slog.LevelInfo
+			if v.Error != nil {
+				attrs = append(attrs, slog.String("error", v.Error.Error()))
+			}
+			if v.Status >= 500 {
+				level = slog.LevelError
+			}
+
+			logger.LogAttrs(c.Request().Context(), level, "http request", attrs...)
+			return nil
+		},
+	})
+}
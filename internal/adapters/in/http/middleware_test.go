@@ -0,0 +1,64 @@
+package http
+
+import (
+	"bytes"
+	"delivery/internal/pkg/correlation"
+	"delivery/internal/pkg/logging"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCorrelationEcho(t *testing.T, output *bytes.Buffer) (*echo.Echo, *[]string) {
+	t.Helper()
+
+	logger, err := logging.New(output, logging.Config{Level: "info", Format: logging.FormatJson})
+	require.NoError(t, err)
+
+	var seen []string
+	e := echo.New()
+	e.Use(CorrelationMiddleware(), RequestLogger(logger))
+	e.GET("/api/v1/orders", func(c echo.Context) error {
+		seen = append(seen, correlation.CorrelationID(c.Request().Context()))
+		return echo.NewHTTPError(http.StatusConflict)
+	})
+	return e, &seen
+}
+
+func TestCorrelationMiddleware_GeneratesIds(t *testing.T) {
+	var output bytes.Buffer
+	e, seen := newCorrelationEcho(t, &output)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil))
+
+	requestID := rec.Header().Get(correlation.RequestIDHeader)
+	require.NotEmpty(t, requestID)
+	assert.Equal(t, requestID, rec.Header().Get(correlation.CorrelationIDHeader))
+	assert.Equal(t, []string{requestID}, *seen)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	assert.Equal(t, requestID, record["request_id"])
+	assert.Equal(t, "/api/v1/orders", record["route"])
+	assert.Equal(t, float64(http.StatusConflict), record["status"])
+}
+
+func TestCorrelationMiddleware_KeepsCallerCorrelationId(t *testing.T) {
+	var output bytes.Buffer
+	e, seen := newCorrelationEcho(t, &output)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req.Header.Set(correlation.CorrelationIDHeader, "basket-checkout-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, "basket-checkout-1", rec.Header().Get(correlation.CorrelationIDHeader))
+	assert.NotEqual(t, "basket-checkout-1", rec.Header().Get(correlation.RequestIDHeader))
+	assert.Equal(t, []string{"basket-checkout-1"}, *seen)
+}
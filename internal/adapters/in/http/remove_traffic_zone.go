@@ -0,0 +1,32 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"net/http"
+)
+
+func (s *Server) RemoveTrafficZone(ctx context.Context, request servers.RemoveTrafficZoneRequestObject) (servers.RemoveTrafficZoneResponseObject, error) {
+	command, err := commands.NewRemoveTrafficZoneCommand(request.Name)
+	if err != nil {
+		return servers.RemoveTrafficZonedefaultJSONResponse{
+			Body:       newError(http.StatusBadRequest, err),
+			StatusCode: http.StatusBadRequest,
+		}, nil
+	}
+
+	if err := s.removeTrafficZoneCommandHandler.Handle(ctx, command); err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return servers.RemoveTrafficZone404JSONResponse(newError(http.StatusNotFound, err)), nil
+		}
+		return servers.RemoveTrafficZonedefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	return servers.RemoveTrafficZone204Response{}, nil
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+var _ servers.StrictServerInterface = &Server{}
+
+type Server struct {
+	createOrderCommandHandler         commands.CreateOrderCommandHandler
+	createCourierCommandHandler       commands.CreateCourierCommandHandler
+	getAllCouriersQueryHandler        queries.GetAllCouriersQueryHandler
+	getNotCompletedOrdersQueryHandler queries.GetNotCompletedOrdersQueryHandler
+	setTrafficZoneCommandHandler      commands.SetTrafficZoneCommandHandler
+	removeTrafficZoneCommandHandler   commands.RemoveTrafficZoneCommandHandler
+	getTrafficZonesQueryHandler       queries.GetTrafficZonesQueryHandler
+	logger                            *slog.Logger
+}
+
+func NewServer(
+	createOrderCommandHandler commands.CreateOrderCommandHandler,
+	createCourierCommandHandler commands.CreateCourierCommandHandler,
+	getAllCouriersQueryHandler queries.GetAllCouriersQueryHandler,
+	getNotCompletedOrdersQueryHandler queries.GetNotCompletedOrdersQueryHandler,
+	setTrafficZoneCommandHandler commands.SetTrafficZoneCommandHandler,
+	removeTrafficZoneCommandHandler commands.RemoveTrafficZoneCommandHandler,
+	getTrafficZonesQueryHandler queries.GetTrafficZonesQueryHandler,
+	logger *slog.Logger,
+) (*Server, error) {
+	if createOrderCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createOrderCommandHandler")
+	}
+	if createCourierCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createCourierCommandHandler")
+	}
+	if getAllCouriersQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getAllCouriersQueryHandler")
+	}
+	if getNotCompletedOrdersQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getNotCompletedOrdersQueryHandler")
+	}
+	if setTrafficZoneCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("setTrafficZoneCommandHandler")
+	}
+	if removeTrafficZoneCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("removeTrafficZoneCommandHandler")
+	}
+	if getTrafficZonesQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getTrafficZonesQueryHandler")
+	}
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	return &Server{
+		createOrderCommandHandler:         createOrderCommandHandler,
+		createCourierCommandHandler:       createCourierCommandHandler,
+		getAllCouriersQueryHandler:        getAllCouriersQueryHandler,
+		getNotCompletedOrdersQueryHandler: getNotCompletedOrdersQueryHandler,
+		setTrafficZoneCommandHandler:      setTrafficZoneCommandHandler,
+		removeTrafficZoneCommandHandler:   removeTrafficZoneCommandHandler,
+		getTrafficZonesQueryHandler:       getTrafficZonesQueryHandler,
+		logger:                            logger,
+	}, nil
+}
+
+func isValidationError(err error) bool {
+	return errors.Is(err, errs.ErrValueIsRequired) ||
+		errors.Is(err, errs.ErrValueIsInvalid) ||
+		errors.Is(err, errs.ErrValueIsOutOfRange)
+}
+
+func newError(code int, err error) servers.Error {
+	return servers.Error{
+		Code:    int32(code),
+		Message: err.Error(),
+	}
+}
+
+// internalError пишет причину в лог и не раскрывает клиенту текст внутренней
+// ошибки. Декораторы обработчиков пишут ошибки только в debug, а в журнале
+// запросов остается лишь код 500.
+func (s *Server) internalError(ctx context.Context, err error) servers.Error {
+	s.logger.ErrorContext(ctx, "cannot handle request", slog.Any("error", err))
+	return servers.Error{
+		Code:    http.StatusInternalServerError,
+		Message: http.StatusText(http.StatusInternalServerError),
+	}
+}
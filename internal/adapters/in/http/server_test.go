@@ -0,0 +1,369 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/logging"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCreateOrderCommandHandler struct {
+	commands []commands.CreateOrderCommand
+	err      error
+}
+
+func (h *stubCreateOrderCommandHandler) Handle(_ context.Context, command commands.CreateOrderCommand) error {
+	h.commands = append(h.commands, command)
+	return h.err
+}
+
+type stubCreateCourierCommandHandler struct {
+	commands []commands.CreateCourierCommand
+	err      error
+}
+
+func (h *stubCreateCourierCommandHandler) Handle(_ context.Context, command commands.CreateCourierCommand) error {
+	h.commands = append(h.commands, command)
+	return h.err
+}
+
+type stubGetAllCouriersQueryHandler struct {
+	response queries.GetAllCouriersResponse
+	err      error
+}
+
+func (h *stubGetAllCouriersQueryHandler) Handle(_ context.Context, _ queries.GetAllCouriersQuery) (queries.GetAllCouriersResponse, error) {
+	return h.response, h.err
+}
+
+type stubGetNotCompletedOrdersQueryHandler struct {
+	response queries.GetNotCompletedOrdersResponse
+	err      error
+}
+
+func (h *stubGetNotCompletedOrdersQueryHandler) Handle(
+	_ context.Context, _ queries.GetNotCompletedOrdersQuery) (queries.GetNotCompletedOrdersResponse, error) {
+	return h.response, h.err
+}
+
+type stubSetTrafficZoneCommandHandler struct {
+	commands []commands.SetTrafficZoneCommand
+	err      error
+}
+
+func (h *stubSetTrafficZoneCommandHandler) Handle(_ context.Context, command commands.SetTrafficZoneCommand) error {
+	h.commands = append(h.commands, command)
+	return h.err
+}
+
+type stubRemoveTrafficZoneCommandHandler struct {
+	commands []commands.RemoveTrafficZoneCommand
+	err      error
+}
+
+func (h *stubRemoveTrafficZoneCommandHandler) Handle(_ context.Context, command commands.RemoveTrafficZoneCommand) error {
+	h.commands = append(h.commands, command)
+	return h.err
+}
+
+type stubGetTrafficZonesQueryHandler struct {
+	response queries.GetTrafficZonesResponse
+	err      error
+}
+
+func (h *stubGetTrafficZonesQueryHandler) Handle(_ context.Context, _ queries.GetTrafficZonesQuery) (queries.GetTrafficZonesResponse, error) {
+	return h.response, h.err
+}
+
+type testServer struct {
+	echo          *echo.Echo
+	createOrder   *stubCreateOrderCommandHandler
+	createCourier *stubCreateCourierCommandHandler
+	getCouriers   *stubGetAllCouriersQueryHandler
+	getOrders     *stubGetNotCompletedOrdersQueryHandler
+	setZone       *stubSetTrafficZoneCommandHandler
+	removeZone    *stubRemoveTrafficZoneCommandHandler
+	getZones      *stubGetTrafficZonesQueryHandler
+	logs          *bytes.Buffer
+}
+
+func newTestServer(t *testing.T) *testServer {
+	ts := &testServer{
+		echo:          echo.New(),
+		createOrder:   &stubCreateOrderCommandHandler{},
+		createCourier: &stubCreateCourierCommandHandler{},
+		getCouriers:   &stubGetAllCouriersQueryHandler{},
+		getOrders:     &stubGetNotCompletedOrdersQueryHandler{},
+		setZone:       &stubSetTrafficZoneCommandHandler{},
+		removeZone:    &stubRemoveTrafficZoneCommandHandler{},
+		getZones:      &stubGetTrafficZonesQueryHandler{},
+		logs:          &bytes.Buffer{},
+	}
+	logger, err := logging.New(ts.logs, logging.Config{Level: "info", Format: logging.FormatJson})
+	require.NoError(t, err)
+
+	server, err := NewServer(ts.createOrder, ts.createCourier, ts.getCouriers, ts.getOrders,
+		ts.setZone, ts.removeZone, ts.getZones, logger)
+	require.NoError(t, err)
+	servers.RegisterHandlers(ts.echo, servers.NewStrictHandler(server, nil))
+
+	return ts
+}
+
+func (ts *testServer) do(method, path, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	recorder := httptest.NewRecorder()
+	ts.echo.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestServer_CreateCourier(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		response := ts.do(http.MethodPost, "/api/v1/couriers", `{"name":"Вело","transport":"bicycle","speed":2}`)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+		require.Len(t, ts.createCourier.commands, 1)
+		assert.Equal(t, "Вело", ts.createCourier.commands[0].Name())
+		assert.Equal(t, kernel.Bicycle, ts.createCourier.commands[0].Transport())
+		assert.Equal(t, 2, ts.createCourier.commands[0].Speed())
+	})
+
+	t.Run("unknown transport", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		response := ts.do(http.MethodPost, "/api/v1/couriers", `{"name":"Грузовик","transport":"truck","speed":2}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "transport")
+		assert.Empty(t, ts.createCourier.commands)
+	})
+
+	t.Run("invalid speed", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		response := ts.do(http.MethodPost, "/api/v1/couriers", `{"name":"Вело","transport":"bicycle","speed":0}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "speed")
+		assert.Empty(t, ts.createCourier.commands)
+	})
+
+	t.Run("handler failure", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.createCourier.err = errors.New("db is down")
+
+		response := ts.do(http.MethodPost, "/api/v1/couriers", `{"name":"Вело","transport":"bicycle","speed":2}`)
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestServer_CreateOrder(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		response := ts.do(http.MethodPost, "/api/v1/orders", `{"street":"Тверская","volume":7}`)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+		require.Len(t, ts.createOrder.commands, 1)
+		assert.NotEqual(t, uuid.Nil, ts.createOrder.commands[0].OrderID())
+		assert.Equal(t, "Тверская", ts.createOrder.commands[0].Street())
+		assert.Equal(t, 7, ts.createOrder.commands[0].Volume())
+	})
+
+	t.Run("created with client order ID", func(t *testing.T) {
+		ts := newTestServer(t)
+		orderID := uuid.New()
+
+		response := ts.do(http.MethodPost, "/api/v1/orders", `{"id":"`+orderID.String()+`","street":"Тверская","volume":7}`)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+		require.Len(t, ts.createOrder.commands, 1)
+		assert.Equal(t, orderID, ts.createOrder.commands[0].OrderID())
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		response := ts.do(http.MethodPost, "/api/v1/orders", `{"street":"","volume":0}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Empty(t, ts.createOrder.commands)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.createOrder.err = errs.NewValueIsInvalidError("street")
+
+		response := ts.do(http.MethodPost, "/api/v1/orders", `{"street":"Несуществующая","volume":7}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("internal error is not exposed", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.createOrder.err = errors.New("pq: password authentication failed")
+
+		response := ts.do(http.MethodPost, "/api/v1/orders", `{"street":"Тверская","volume":7}`)
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.NotContains(t, response.Body.String(), "password")
+		assert.Contains(t, ts.logs.String(), "pq: password authentication failed")
+	})
+}
+
+func TestServer_GetCouriers(t *testing.T) {
+	ts := newTestServer(t)
+	courierID := uuid.New()
+	ts.getCouriers.response = queries.GetAllCouriersResponse{Couriers: []queries.CourierResponse{
+		{ID: courierID, Name: "Пеший", Transport: "pedestrian", Location: queries.LocationResponse{X: 1, Y: 2}},
+	}}
+
+	response := ts.do(http.MethodGet, "/api/v1/couriers", "")
+
+	require.Equal(t, http.StatusOK, response.Code)
+	var couriers []servers.Courier
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &couriers))
+	require.Len(t, couriers, 1)
+	assert.Equal(t, courierID, couriers[0].Id)
+	assert.Equal(t, "Пеший", couriers[0].Name)
+	assert.Equal(t, servers.Pedestrian, couriers[0].Transport)
+	assert.Equal(t, servers.Location{X: 1, Y: 2}, couriers[0].Location)
+}
+
+func TestServer_GetOrders(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ts := newTestServer(t)
+		orderID := uuid.New()
+		ts.getOrders.response = queries.GetNotCompletedOrdersResponse{Orders: []queries.OrderResponse{
+			{ID: orderID, Location: queries.LocationResponse{X: 3, Y: 4}},
+		}}
+
+		response := ts.do(http.MethodGet, "/api/v1/orders/active", "")
+
+		require.Equal(t, http.StatusOK, response.Code)
+		var orders []servers.Order
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &orders))
+		require.Len(t, orders, 1)
+		assert.Equal(t, orderID, orders[0].Id)
+		assert.Equal(t, servers.Location{X: 3, Y: 4}, orders[0].Location)
+	})
+
+	t.Run("empty list is an array", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		response := ts.do(http.MethodGet, "/api/v1/orders/active", "")
+
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, "[]", response.Body.String())
+	})
+
+	t.Run("handler failure", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.getOrders.err = errors.New("db is down")
+
+		response := ts.do(http.MethodGet, "/api/v1/orders/active", "")
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestServer_GetTrafficZones(t *testing.T) {
+	ts := newTestServer(t)
+	ts.getZones.response = queries.GetTrafficZonesResponse{TrafficZones: []queries.TrafficZoneResponse{{
+		Name:        "center",
+		From:        queries.LocationResponse{X: 3, Y: 3},
+		To:          queries.LocationResponse{X: 6, Y: 6},
+		Multipliers: map[string]float64{"car": 2.5},
+	}}}
+
+	response := ts.do(http.MethodGet, "/api/v1/traffic-zones", "")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `[{"name":"center","from":{"x":3,"y":3},"to":{"x":6,"y":6},"multipliers":{"car":2.5}}]`,
+		response.Body.String())
+}
+
+func TestServer_SetTrafficZone(t *testing.T) {
+	t.Run("sets zone", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		response := ts.do(http.MethodPut, "/api/v1/traffic-zones/center",
+			`{"from":{"x":3,"y":3},"to":{"x":6,"y":6},"multipliers":{"car":2.5}}`)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		require.Len(t, ts.setZone.commands, 1)
+		assert.Equal(t, "center", ts.setZone.commands[0].Name())
+		assert.Equal(t, 6, ts.setZone.commands[0].ToX())
+		assert.Equal(t, map[string]float64{"car": 2.5}, ts.setZone.commands[0].Multipliers())
+	})
+
+	t.Run("rejects zone without multipliers", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		response := ts.do(http.MethodPut, "/api/v1/traffic-zones/center", `{"from":{"x":3,"y":3},"to":{"x":6,"y":6}}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "multipliers")
+		assert.Empty(t, ts.setZone.commands)
+	})
+
+	t.Run("maps validation error to 400", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.setZone.err = errs.NewValueIsInvalidError("transport")
+
+		response := ts.do(http.MethodPut, "/api/v1/traffic-zones/center",
+			`{"from":{"x":3,"y":3},"to":{"x":6,"y":6},"multipliers":{"truck":2}}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("handler failure", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.setZone.err = errors.New("db is down")
+
+		response := ts.do(http.MethodPut, "/api/v1/traffic-zones/center",
+			`{"from":{"x":3,"y":3},"to":{"x":6,"y":6},"multipliers":{"car":2.5}}`)
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestServer_RemoveTrafficZone(t *testing.T) {
+	t.Run("removes zone", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		response := ts.do(http.MethodDelete, "/api/v1/traffic-zones/center", "")
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		require.Len(t, ts.removeZone.commands, 1)
+		assert.Equal(t, "center", ts.removeZone.commands[0].Name())
+	})
+
+	t.Run("unknown zone", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.removeZone.err = errs.NewObjectNotFoundError("name", "center")
+
+		response := ts.do(http.MethodDelete, "/api/v1/traffic-zones/center", "")
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"net/http"
+)
+
+// SetTrafficZone создает район или заменяет его целиком. Имя берется из пути.
+func (s *Server) SetTrafficZone(ctx context.Context, request servers.SetTrafficZoneRequestObject) (servers.SetTrafficZoneResponseObject, error) {
+	if request.Body == nil {
+		return servers.SetTrafficZone400JSONResponse(newError(http.StatusBadRequest, errs.NewValueIsRequiredError("body"))), nil
+	}
+
+	command, err := commands.NewSetTrafficZoneCommand(
+		request.Name,
+		request.Body.From.X, request.Body.From.Y,
+		request.Body.To.X, request.Body.To.Y,
+		request.Body.Multipliers,
+	)
+	if err != nil {
+		return servers.SetTrafficZone400JSONResponse(newError(http.StatusBadRequest, err)), nil
+	}
+
+	if err := s.setTrafficZoneCommandHandler.Handle(ctx, command); err != nil {
+		if isValidationError(err) {
+			return servers.SetTrafficZone400JSONResponse(newError(http.StatusBadRequest, err)), nil
+		}
+		return servers.SetTrafficZonedefaultJSONResponse{
+			Body:       s.internalError(ctx, err),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	return servers.SetTrafficZone204Response{}, nil
+}
@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"log/slog"
+)
+
+var _ Job = &AssignOrdersJob{}
+
+type AssignOrdersJob struct {
+	assignOrdersCommandHandler commands.AssignOrdersCommandHandler
+	logger                     *slog.Logger
+}
+
+func NewAssignOrdersJob(assignOrdersCommandHandler commands.AssignOrdersCommandHandler, logger *slog.Logger) (*AssignOrdersJob, error) {
+	if assignOrdersCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("assignOrdersCommandHandler")
+	}
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	return &AssignOrdersJob{
+		assignOrdersCommandHandler: assignOrdersCommandHandler,
+		logger:                     logger,
+	}, nil
+}
+
+func (j *AssignOrdersJob) Run(ctx context.Context) error {
+	command, err := commands.NewAssignOrdersCommand()
+	if err != nil {
+		return err
+	}
+
+	err = j.assignOrdersCommandHandler.Handle(ctx, command)
+	if errors.Is(err, commands.ErrNoSuitableCourier) {
+		// Заказ остается в статусе Created и будет назначен на следующем тике
+		j.logger.InfoContext(ctx, "order is not assigned", slog.Any("reason", err))
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/pkg/logging"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAssignOrdersCommandHandler struct {
+	err   error
+	calls int
+}
+
+func (h *stubAssignOrdersCommandHandler) Handle(_ context.Context, command commands.AssignOrdersCommand) error {
+	h.calls++
+	if command.IsEmpty() {
+		return errors.New("empty command")
+	}
+	return h.err
+}
+
+func TestAssignOrdersJob_Run(t *testing.T) {
+	t.Run("no suitable courier is not a failure", func(t *testing.T) {
+		handler := &stubAssignOrdersCommandHandler{err: fmt.Errorf("%w: order", commands.ErrNoSuitableCourier)}
+		job, err := NewAssignOrdersJob(handler, logging.Discard())
+		require.NoError(t, err)
+
+		assert.NoError(t, job.Run(context.Background()))
+		assert.Equal(t, 1, handler.calls)
+	})
+
+	t.Run("other errors are returned", func(t *testing.T) {
+		handler := &stubAssignOrdersCommandHandler{err: errors.New("db is down")}
+		job, err := NewAssignOrdersJob(handler, logging.Discard())
+		require.NoError(t, err)
+
+		assert.Error(t, job.Run(context.Background()))
+	})
+}
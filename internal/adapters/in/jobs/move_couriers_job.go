@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/pkg/errs"
+)
+
+var _ Job = &MoveCouriersJob{}
+
+type MoveCouriersJob struct {
+	moveCouriersCommandHandler commands.MoveCouriersCommandHandler
+}
+
+func NewMoveCouriersJob(moveCouriersCommandHandler commands.MoveCouriersCommandHandler) (*MoveCouriersJob, error) {
+	if moveCouriersCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("moveCouriersCommandHandler")
+	}
+
+	return &MoveCouriersJob{
+		moveCouriersCommandHandler: moveCouriersCommandHandler,
+	}, nil
+}
+
+func (j *MoveCouriersJob) Run(ctx context.Context) error {
+	command, err := commands.NewMoveCouriersCommand()
+	if err != nil {
+		return err
+	}
+
+	return j.moveCouriersCommandHandler.Handle(ctx, command)
+}
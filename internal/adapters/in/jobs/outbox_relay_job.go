@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"context"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/outbox"
+)
+
+var _ Job = &OutboxRelayJob{}
+
+type OutboxRelayJob struct {
+	relay *outbox.Relay
+}
+
+func NewOutboxRelayJob(relay *outbox.Relay) (*OutboxRelayJob, error) {
+	if relay == nil {
+		return nil, errs.NewValueIsRequiredError("relay")
+	}
+
+	return &OutboxRelayJob{
+		relay: relay,
+	}, nil
+}
+
+func (j *OutboxRelayJob) Run(ctx context.Context) error {
+	_, err := j.relay.PublishPending(ctx)
+	return err
+}
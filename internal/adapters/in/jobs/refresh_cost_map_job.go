@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"delivery/internal/core/application/costmap"
+	"delivery/internal/pkg/errs"
+)
+
+var _ Job = &RefreshCostMapJob{}
+
+// RefreshCostMapJob подхватывает районы пробок, измененные через другие
+// экземпляры сервиса или прямо в БД
+type RefreshCostMapJob struct {
+	costMapHolder *costmap.Holder
+}
+
+func NewRefreshCostMapJob(costMapHolder *costmap.Holder) (*RefreshCostMapJob, error) {
+	if costMapHolder == nil {
+		return nil, errs.NewValueIsRequiredError("costMapHolder")
+	}
+
+	return &RefreshCostMapJob{
+		costMapHolder: costMapHolder,
+	}, nil
+}
+
+func (j *RefreshCostMapJob) Run(ctx context.Context) error {
+	return j.costMapHolder.Refresh(ctx)
+}
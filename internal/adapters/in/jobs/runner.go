@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"delivery/internal/pkg/correlation"
+	"delivery/internal/pkg/errs"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Job interface {
+	Run(ctx context.Context) error
+}
+
+// Runner периодически запускает Job в отдельной горутине. Запуски не
+// перекрываются: следующий тик ждет завершения предыдущего. Отмена контекста
+// или Close останавливают тики, но начатый запуск доводится до конца.
+type Runner struct {
+	name     string
+	interval time.Duration
+	job      Job
+	logger   *slog.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewRunner(name string, interval time.Duration, job Job, logger *slog.Logger) (*Runner, error) {
+	if name == "" {
+		return nil, errs.NewValueIsRequiredError("name")
+	}
+	if interval <= 0 {
+		return nil, errs.NewValueIsInvalidError("interval")
+	}
+	if job == nil {
+		return nil, errs.NewValueIsRequiredError("job")
+	}
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	return &Runner{
+		name:     name,
+		interval: interval,
+		job:      job,
+		logger:   logger.With(slog.String("job", name)),
+	}, nil
+}
+
+func (r *Runner) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	jobCtx := context.WithoutCancel(ctx)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// select выбирает случайно, если готовы оба канала
+				if ctx.Err() != nil {
+					return
+				}
+				// Каждый запуск — отдельная цепочка correlation ID
+				runCtx := correlation.EnsureCorrelationID(jobCtx)
+				if err := r.job.Run(runCtx); err != nil {
+					r.logger.ErrorContext(runCtx, "job failed", slog.Any("error", err))
+				}
+			}
+		}
+	}()
+}
+
+func (r *Runner) Close() error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
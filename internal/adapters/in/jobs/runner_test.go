@@ -0,0 +1,101 @@
+package jobs
+
+import (
+	"context"
+	"delivery/internal/pkg/logging"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingJob struct {
+	runs atomic.Int32
+	err  error
+}
+
+func (j *countingJob) Run(_ context.Context) error {
+	j.runs.Add(1)
+	return j.err
+}
+
+func TestNewRunner(t *testing.T) {
+	_, err := NewRunner("", time.Second, &countingJob{}, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = NewRunner("job", 0, &countingJob{}, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = NewRunner("job", time.Second, nil, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = NewRunner("job", time.Second, &countingJob{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRunner_RunsJobUntilClosed(t *testing.T) {
+	job := &countingJob{err: errors.New("boom")}
+	runner, err := NewRunner("job", 5*time.Millisecond, job, logging.Discard())
+	require.NoError(t, err)
+
+	runner.Start(context.Background())
+	assert.Eventually(t, func() bool { return job.runs.Load() >= 2 }, time.Second, time.Millisecond)
+
+	require.NoError(t, runner.Close())
+	runs := job.runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, runs, job.runs.Load())
+}
+
+func TestRunner_CloseWithoutStart(t *testing.T) {
+	runner, err := NewRunner("job", time.Second, &countingJob{}, logging.Discard())
+	require.NoError(t, err)
+
+	assert.NoError(t, runner.Close())
+}
+
+type blockingJob struct {
+	started  chan struct{}
+	release  chan struct{}
+	finished atomic.Bool
+	ctxErr   error
+}
+
+func (j *blockingJob) Run(ctx context.Context) error {
+	close(j.started)
+	<-j.release
+	j.ctxErr = ctx.Err()
+	j.finished.Store(true)
+	return nil
+}
+
+func TestRunner_CloseWaitsForRunningJob(t *testing.T) {
+	job := &blockingJob{started: make(chan struct{}), release: make(chan struct{})}
+	runner, err := NewRunner("job", time.Millisecond, job, logging.Discard())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.Start(ctx)
+	<-job.started
+
+	cancel()
+	closed := make(chan struct{})
+	go func() {
+		_ = runner.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("Close returned before running job finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(job.release)
+	<-closed
+	assert.True(t, job.finished.Load())
+	assert.NoError(t, job.ctxErr)
+}
@@ -0,0 +1,380 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/generated/queues/basketconfirmedpb"
+	"delivery/internal/pkg/correlation"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/metrics"
+	"delivery/internal/pkg/tracing"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	consumeRetryInterval = time.Second
+
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 200 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// Заголовки, которыми сообщение дополняется при отправке в dead letter топик
+const (
+	deadLetterReasonHeader    = "x-dead-letter-reason"
+	deadLetterTopicHeader     = "x-original-topic"
+	deadLetterPartitionHeader = "x-original-partition"
+	deadLetterOffsetHeader    = "x-original-offset"
+)
+
+// errConsumeStopped — сессия завершилась во время паузы между попытками.
+// Сообщение не коммитится и будет прочитано заново.
+var errConsumeStopped = errors.New("consume session stopped")
+
+var _ sarama.ConsumerGroupHandler = &BasketConfirmedConsumer{}
+
+// BasketConfirmedConsumer читает топик basket.confirmed в составе consumer group
+// и создает по каждому событию заказ. Offset фиксируется только после успешной
+// обработки, поэтому при падении сообщение будет прочитано повторно — это
+// безопасно, так как CreateOrderCommandHandler идемпотентен. Временную ошибку
+// обработчика повторяем с экспоненциальной паузой, а после maxAttempts попыток,
+// как и неразборчивое сообщение или ошибку валидации, отправляем в dead letter
+// топик, чтобы не блокировать партицию.
+type BasketConfirmedConsumer struct {
+	topic                     string
+	deadLetterTopic           string
+	consumerGroup             sarama.ConsumerGroup
+	deadLetterProducer        sarama.SyncProducer
+	createOrderCommandHandler commands.CreateOrderCommandHandler
+	logger                    *slog.Logger
+
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewBasketConfirmedConsumer(
+	brokers []string,
+	group string,
+	topic string,
+	deadLetterTopic string,
+	createOrderCommandHandler commands.CreateOrderCommandHandler,
+	logger *slog.Logger,
+) (*BasketConfirmedConsumer, error) {
+	if len(brokers) == 0 {
+		return nil, errs.NewValueIsRequiredError("brokers")
+	}
+	if group == "" {
+		return nil, errs.NewValueIsRequiredError("group")
+	}
+
+	config := sarama.NewConfig()
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Offsets.AutoCommit.Enable = false
+	config.Consumer.Return.Errors = true
+
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+
+	consumerGroup, err := sarama.NewConsumerGroup(brokers, group, config)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetterProducer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		_ = consumerGroup.Close()
+		return nil, err
+	}
+
+	consumer, err := newBasketConfirmedConsumer(consumerGroup, deadLetterProducer, topic, deadLetterTopic, createOrderCommandHandler, logger)
+	if err != nil {
+		_ = deadLetterProducer.Close()
+		_ = consumerGroup.Close()
+		return nil, err
+	}
+	return consumer, nil
+}
+
+func newBasketConfirmedConsumer(
+	consumerGroup sarama.ConsumerGroup,
+	deadLetterProducer sarama.SyncProducer,
+	topic string,
+	deadLetterTopic string,
+	createOrderCommandHandler commands.CreateOrderCommandHandler,
+	logger *slog.Logger,
+) (*BasketConfirmedConsumer, error) {
+	if consumerGroup == nil {
+		return nil, errs.NewValueIsRequiredError("consumerGroup")
+	}
+	if deadLetterProducer == nil {
+		return nil, errs.NewValueIsRequiredError("deadLetterProducer")
+	}
+	if topic == "" {
+		return nil, errs.NewValueIsRequiredError("topic")
+	}
+	if deadLetterTopic == "" {
+		return nil, errs.NewValueIsRequiredError("deadLetterTopic")
+	}
+	if deadLetterTopic == topic {
+		return nil, errs.NewValueIsInvalidError("deadLetterTopic")
+	}
+	if createOrderCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createOrderCommandHandler")
+	}
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	return &BasketConfirmedConsumer{
+		topic:                     topic,
+		deadLetterTopic:           deadLetterTopic,
+		consumerGroup:             consumerGroup,
+		deadLetterProducer:        deadLetterProducer,
+		createOrderCommandHandler: createOrderCommandHandler,
+		logger:                    logger.With(slog.String("topic", topic)),
+		maxAttempts:               defaultMaxAttempts,
+		initialBackoff:            defaultInitialBackoff,
+		maxBackoff:                defaultMaxBackoff,
+	}, nil
+}
+
+// Start запускает чтение в отдельной горутине. Consume возвращается при каждой
+// ребалансировке или ошибке обработки, поэтому вызываем его в цикле.
+func (c *BasketConfirmedConsumer) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for {
+			err := c.consumerGroup.Consume(ctx, []string{c.topic}, c)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+					return
+				}
+				c.logger.ErrorContext(ctx, "consumer failed", slog.Any("error", err))
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(consumeRetryInterval):
+				}
+			}
+		}
+	}()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err, ok := <-c.consumerGroup.Errors():
+				if !ok {
+					return
+				}
+				c.logger.ErrorContext(ctx, "consumer reported error", slog.Any("error", err))
+			}
+		}
+	}()
+}
+
+func (c *BasketConfirmedConsumer) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	return errors.Join(c.consumerGroup.Close(), c.deadLetterProducer.Close())
+}
+
+func (c *BasketConfirmedConsumer) Setup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (c *BasketConfirmedConsumer) Cleanup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim обрабатывает сообщения партиции по порядку. Если сессия
+// завершилась во время повторов или dead letter топик недоступен, сообщение
+// остается незакоммиченным и после повторного подключения придет снова.
+func (c *BasketConfirmedConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case <-session.Context().Done():
+			return nil
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			// Начатое сообщение доводим до конца даже при остановке сервиса,
+			// прерываются только паузы между попытками
+			err := c.handle(context.WithoutCancel(session.Context()), session.Context().Done(), message)
+			if errors.Is(err, errConsumeStopped) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			session.MarkMessage(message, "")
+			session.Commit()
+		}
+	}
+}
+
+// handle продолжает трассу и цепочку correlation ID отправителя из заголовков
+// сообщения. Причиной (causation) обработки считается само сообщение.
+func (c *BasketConfirmedConsumer) handle(ctx context.Context, stop <-chan struct{}, message *sarama.ConsumerMessage) (err error) {
+	headers := messageHeaders(message)
+	ctx = withMessageCorrelation(tracing.Extract(ctx, headers), message, headers)
+	ctx, span := tracing.Start(ctx, message.Topic+" process", trace.WithSpanKind(trace.SpanKindConsumer))
+	defer func() { tracing.End(span, err) }()
+
+	logger := c.logger.With(slog.Int("partition", int(message.Partition)), slog.Int64("offset", message.Offset))
+
+	command, err := c.decode(message)
+	if err != nil {
+		logger.WarnContext(ctx, "malformed message", slog.Any("error", err))
+		metrics.ObserveKafkaConsumed(message.Topic, metrics.KafkaResultMalformed)
+		span.RecordError(err)
+		return c.deadLetter(ctx, message, err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.createOrderCommandHandler.Handle(ctx, command)
+		if err == nil {
+			metrics.ObserveKafkaConsumed(message.Topic, metrics.KafkaResultSuccess)
+			return nil
+		}
+		if isPermanentError(err) {
+			logger.WarnContext(ctx, "message rejected", slog.Any("error", err))
+			metrics.ObserveKafkaConsumed(message.Topic, metrics.KafkaResultRejected)
+			span.RecordError(err)
+			return c.deadLetter(ctx, message, err)
+		}
+
+		logger.ErrorContext(ctx, "cannot handle message", slog.Int("attempt", attempt), slog.Any("error", err))
+		metrics.ObserveKafkaConsumed(message.Topic, metrics.KafkaResultError)
+		span.RecordError(err)
+		if attempt >= c.maxAttempts {
+			return c.deadLetter(ctx, message, err)
+		}
+
+		select {
+		case <-stop:
+			return errConsumeStopped
+		case <-time.After(c.backoff(attempt)):
+		}
+	}
+}
+
+// isPermanentError отличает ошибки самого сообщения (неизвестная или
+// непроходимая улица, неверный объем) от временных сбоев базы или геосервиса:
+// повтор такого сообщения дал бы тот же результат
+func isPermanentError(err error) bool {
+	return errors.Is(err, errs.ErrValueIsRequired) ||
+		errors.Is(err, errs.ErrValueIsInvalid) ||
+		errors.Is(err, errs.ErrValueIsOutOfRange) ||
+		errors.Is(err, commands.ErrImpassableOrderLocation)
+}
+
+// backoff удваивает паузу после каждой неудачной попытки, но не больше maxBackoff
+func (c *BasketConfirmedConsumer) backoff(attempt int) time.Duration {
+	delay := c.initialBackoff
+	for i := 1; i < attempt && delay < c.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, c.maxBackoff)
+}
+
+// deadLetter пересылает исходное сообщение с тем же ключом и заголовками,
+// добавляя причину и координаты оригинала
+func (c *BasketConfirmedConsumer) deadLetter(ctx context.Context, message *sarama.ConsumerMessage, reason error) error {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+4)
+	for _, header := range message.Headers {
+		if header != nil {
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(deadLetterReasonHeader), Value: []byte(reason.Error())},
+		sarama.RecordHeader{Key: []byte(deadLetterTopicHeader), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(deadLetterPartitionHeader), Value: []byte(strconv.Itoa(int(message.Partition)))},
+		sarama.RecordHeader{Key: []byte(deadLetterOffsetHeader), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+	)
+
+	_, _, err := c.deadLetterProducer.SendMessage(&sarama.ProducerMessage{
+		Topic:   c.deadLetterTopic,
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	})
+	metrics.ObserveKafkaProduced(c.deadLetterTopic, err)
+	if err != nil {
+		return fmt.Errorf("failed to dead letter message: %w", err)
+	}
+
+	c.logger.WarnContext(ctx, "message sent to dead letter topic",
+		slog.Int("partition", int(message.Partition)),
+		slog.Int64("offset", message.Offset),
+		slog.String("deadLetterTopic", c.deadLetterTopic),
+	)
+	metrics.ObserveKafkaConsumed(message.Topic, metrics.KafkaResultDeadLettered)
+	return nil
+}
+
+func messageHeaders(message *sarama.ConsumerMessage) map[string]string {
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		if header != nil {
+			headers[string(header.Key)] = string(header.Value)
+		}
+	}
+	return headers
+}
+
+// withMessageCorrelation начинает новую цепочку, если отправитель не передал
+// correlation ID. Без X-Message-ID причиной служат координаты сообщения.
+func withMessageCorrelation(ctx context.Context, message *sarama.ConsumerMessage, headers map[string]string) context.Context {
+	correlationID := headers[correlation.CorrelationIDHeader]
+	if correlationID == "" {
+		correlationID = uuid.NewString()
+	}
+
+	causationID := headers[correlation.MessageIDHeader]
+	if causationID == "" {
+		causationID = fmt.Sprintf("%s/%d/%d", message.Topic, message.Partition, message.Offset)
+	}
+
+	return correlation.WithCausationID(correlation.WithCorrelationID(ctx, correlationID), causationID)
+}
+
+func (c *BasketConfirmedConsumer) decode(message *sarama.ConsumerMessage) (commands.CreateOrderCommand, error) {
+	event := &basketconfirmedpb.BasketConfirmedIntegrationEvent{}
+	if err := proto.Unmarshal(message.Value, event); err != nil {
+		return commands.CreateOrderCommand{}, err
+	}
+	return mapBasketConfirmedToCreateOrderCommand(event)
+}
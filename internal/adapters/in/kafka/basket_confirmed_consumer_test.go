@@ -0,0 +1,476 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/generated/queues/basketconfirmedpb"
+	"delivery/internal/pkg/correlation"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/logging"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testTopic           = "basket.confirmed"
+	testDeadLetterTopic = "basket.confirmed.dlq"
+)
+
+type recordingCreateOrderHandler struct {
+	mu       sync.Mutex
+	commands []commands.CreateOrderCommand
+	ctx      context.Context
+	ctxs     []context.Context
+	err      error
+	// failures — сколько первых вызовов вернут err, 0 — все
+	failures int
+	calls    int
+}
+
+func (h *recordingCreateOrderHandler) Handle(ctx context.Context, command commands.CreateOrderCommand) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ctx = ctx
+	h.ctxs = append(h.ctxs, ctx)
+	h.calls++
+	if h.err != nil && (h.failures == 0 || h.calls <= h.failures) {
+		return h.err
+	}
+	h.commands = append(h.commands, command)
+	return nil
+}
+
+func (h *recordingCreateOrderHandler) handled() []commands.CreateOrderCommand {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]commands.CreateOrderCommand(nil), h.commands...)
+}
+
+// fakeBroker — in-process замена брокера: хранит сообщения одной партиции
+// и закоммиченный offset группы.
+type fakeBroker struct {
+	mu        sync.Mutex
+	messages  chan *sarama.ConsumerMessage
+	committed int64
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{messages: make(chan *sarama.ConsumerMessage, 16), committed: -1}
+}
+
+func (b *fakeBroker) produce(offset int64, value []byte) {
+	b.messages <- &sarama.ConsumerMessage{Topic: testTopic, Offset: offset, Value: value}
+}
+
+func (b *fakeBroker) committedOffset() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.committed
+}
+
+type fakeSession struct {
+	ctx    context.Context
+	broker *fakeBroker
+	marked []int64
+}
+
+func (s *fakeSession) Claims() map[string][]int32               { return map[string][]int32{testTopic: {0}} }
+func (s *fakeSession) MemberID() string                         { return "member" }
+func (s *fakeSession) GenerationID() int32                      { return 1 }
+func (s *fakeSession) MarkOffset(string, int32, int64, string)  {}
+func (s *fakeSession) ResetOffset(string, int32, int64, string) {}
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+func (s *fakeSession) Commit() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	if len(s.marked) > 0 {
+		s.broker.committed = s.marked[len(s.marked)-1]
+	}
+}
+func (s *fakeSession) Context() context.Context { return s.ctx }
+
+type fakeClaim struct {
+	broker *fakeBroker
+}
+
+func (c *fakeClaim) Topic() string                            { return testTopic }
+func (c *fakeClaim) Partition() int32                         { return 0 }
+func (c *fakeClaim) InitialOffset() int64                     { return 0 }
+func (c *fakeClaim) HighWaterMarkOffset() int64               { return 0 }
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.broker.messages }
+
+type fakeConsumerGroup struct {
+	broker *fakeBroker
+	errors chan error
+	closed bool
+}
+
+func newFakeConsumerGroup(broker *fakeBroker) *fakeConsumerGroup {
+	return &fakeConsumerGroup{broker: broker, errors: make(chan error)}
+}
+
+func (g *fakeConsumerGroup) Consume(ctx context.Context, _ []string, handler sarama.ConsumerGroupHandler) error {
+	session := &fakeSession{ctx: ctx, broker: g.broker}
+	if err := handler.Setup(session); err != nil {
+		return err
+	}
+	err := handler.ConsumeClaim(session, &fakeClaim{broker: g.broker})
+	if cleanupErr := handler.Cleanup(session); err == nil {
+		err = cleanupErr
+	}
+	return err
+}
+
+func (g *fakeConsumerGroup) Errors() <-chan error { return g.errors }
+func (g *fakeConsumerGroup) Close() error {
+	g.closed = true
+	return nil
+}
+func (g *fakeConsumerGroup) Pause(map[string][]int32)  {}
+func (g *fakeConsumerGroup) Resume(map[string][]int32) {}
+func (g *fakeConsumerGroup) PauseAll()                 {}
+func (g *fakeConsumerGroup) ResumeAll()                {}
+
+func mustMarshalEvent(t *testing.T, basketID string, street string, volume int32) []byte {
+	t.Helper()
+	value, err := proto.Marshal(&basketconfirmedpb.BasketConfirmedIntegrationEvent{
+		BasketId: basketID,
+		Address:  &basketconfirmedpb.Address{Country: "Россия", City: "Москва", Street: street},
+		Volume:   volume,
+	})
+	require.NoError(t, err)
+	return value
+}
+
+// newTestConsumer собирает consumer с короткими паузами между попытками
+func newTestConsumer(t *testing.T, group sarama.ConsumerGroup, deadLetters sarama.SyncProducer, handler commands.CreateOrderCommandHandler) *BasketConfirmedConsumer {
+	t.Helper()
+	if deadLetters == nil {
+		deadLetters = mocks.NewSyncProducer(t, newProducerConfig())
+	}
+	consumer, err := newBasketConfirmedConsumer(group, deadLetters, testTopic, testDeadLetterTopic, handler, logging.Discard())
+	require.NoError(t, err)
+	consumer.maxAttempts = 3
+	consumer.initialBackoff = time.Millisecond
+	consumer.maxBackoff = 2 * time.Millisecond
+	return consumer
+}
+
+func newProducerConfig() *sarama.Config {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	return config
+}
+
+func TestNewBasketConfirmedConsumer_Validation(t *testing.T) {
+	handler := &recordingCreateOrderHandler{}
+	group := newFakeConsumerGroup(newFakeBroker())
+	deadLetters := mocks.NewSyncProducer(t, newProducerConfig())
+
+	_, err := newBasketConfirmedConsumer(nil, deadLetters, testTopic, testDeadLetterTopic, handler, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = newBasketConfirmedConsumer(group, nil, testTopic, testDeadLetterTopic, handler, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = newBasketConfirmedConsumer(group, deadLetters, "", testDeadLetterTopic, handler, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = newBasketConfirmedConsumer(group, deadLetters, testTopic, "", handler, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = newBasketConfirmedConsumer(group, deadLetters, testTopic, testTopic, handler, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = newBasketConfirmedConsumer(group, deadLetters, testTopic, testDeadLetterTopic, nil, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = newBasketConfirmedConsumer(group, deadLetters, testTopic, testDeadLetterTopic, handler, nil)
+	assert.Error(t, err)
+
+	_, err = NewBasketConfirmedConsumer(nil, "group", testTopic, testDeadLetterTopic, handler, logging.Discard())
+	assert.Error(t, err)
+}
+
+func TestBasketConfirmedConsumer_ConsumeClaim_CommitsAfterSuccess(t *testing.T) {
+	broker := newFakeBroker()
+	handler := &recordingCreateOrderHandler{}
+	consumer := newTestConsumer(t, newFakeConsumerGroup(broker), nil, handler)
+
+	basketID := uuid.New()
+	broker.produce(0, mustMarshalEvent(t, basketID.String(), "Тверская", 3))
+	close(broker.messages)
+
+	session := &fakeSession{ctx: context.Background(), broker: broker}
+	require.NoError(t, consumer.ConsumeClaim(session, &fakeClaim{broker: broker}))
+
+	handled := handler.handled()
+	require.Len(t, handled, 1)
+	assert.Equal(t, basketID, handled[0].OrderID())
+	assert.Equal(t, "Тверская", handled[0].Street())
+	assert.Equal(t, 3, handled[0].Volume())
+	assert.Equal(t, int64(0), broker.committedOffset())
+}
+
+func TestBasketConfirmedConsumer_ConsumeClaim_RetriesHandlerError(t *testing.T) {
+	broker := newFakeBroker()
+	handler := &recordingCreateOrderHandler{err: errors.New("db is down"), failures: 2}
+	consumer := newTestConsumer(t, newFakeConsumerGroup(broker), nil, handler)
+
+	broker.produce(0, mustMarshalEvent(t, uuid.NewString(), "Тверская", 3))
+	close(broker.messages)
+
+	session := &fakeSession{ctx: context.Background(), broker: broker}
+	require.NoError(t, consumer.ConsumeClaim(session, &fakeClaim{broker: broker}))
+
+	assert.Equal(t, 3, handler.calls)
+	assert.Len(t, handler.handled(), 1)
+	assert.Equal(t, int64(0), broker.committedOffset())
+}
+
+func TestBasketConfirmedConsumer_ConsumeClaim_DeadLettersAfterMaxAttempts(t *testing.T) {
+	broker := newFakeBroker()
+	handler := &recordingCreateOrderHandler{err: errors.New("db is down")}
+	deadLetters := mocks.NewSyncProducer(t, newProducerConfig())
+	deadLetters.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		assert.Equal(t, testDeadLetterTopic, message.Topic)
+		headers := make(map[string]string)
+		for _, header := range message.Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		assert.Equal(t, "db is down", headers[deadLetterReasonHeader])
+		assert.Equal(t, testTopic, headers[deadLetterTopicHeader])
+		assert.Equal(t, "0", headers[deadLetterOffsetHeader])
+		return nil
+	})
+	consumer := newTestConsumer(t, newFakeConsumerGroup(broker), deadLetters, handler)
+
+	broker.produce(0, mustMarshalEvent(t, uuid.NewString(), "Тверская", 3))
+	close(broker.messages)
+
+	session := &fakeSession{ctx: context.Background(), broker: broker}
+	require.NoError(t, consumer.ConsumeClaim(session, &fakeClaim{broker: broker}))
+
+	assert.Equal(t, 3, handler.calls)
+	assert.Equal(t, int64(0), broker.committedOffset())
+	require.NoError(t, deadLetters.Close())
+}
+
+func TestBasketConfirmedConsumer_ConsumeClaim_DeadLettersPermanentErrorsAtOnce(t *testing.T) {
+	tests := map[string]error{
+		"unknown street":  errs.NewValueIsInvalidErrorWithCause("street", errors.New("not found")),
+		"impassable cell": fmt.Errorf("%w: %w", errs.NewValueIsInvalidError("location"), commands.ErrImpassableOrderLocation),
+		"out of grid":     errs.NewValueIsOutOfRangeError("x", 20, 1, 10),
+	}
+
+	for name, handlerErr := range tests {
+		t.Run(name, func(t *testing.T) {
+			broker := newFakeBroker()
+			handler := &recordingCreateOrderHandler{err: handlerErr}
+			deadLetters := mocks.NewSyncProducer(t, newProducerConfig())
+			deadLetters.ExpectSendMessageAndSucceed()
+			consumer := newTestConsumer(t, newFakeConsumerGroup(broker), deadLetters, handler)
+
+			broker.produce(0, mustMarshalEvent(t, uuid.NewString(), "Несуществующая", 3))
+			close(broker.messages)
+
+			session := &fakeSession{ctx: context.Background(), broker: broker}
+			require.NoError(t, consumer.ConsumeClaim(session, &fakeClaim{broker: broker}))
+
+			assert.Equal(t, 1, handler.calls)
+			assert.Equal(t, int64(0), broker.committedOffset())
+			require.NoError(t, deadLetters.Close())
+		})
+	}
+}
+
+func TestBasketConfirmedConsumer_ConsumeClaim_DoesNotCommitWhenDeadLetterFails(t *testing.T) {
+	broker := newFakeBroker()
+	handler := &recordingCreateOrderHandler{err: errors.New("db is down")}
+	deadLetters := mocks.NewSyncProducer(t, newProducerConfig())
+	deadLetters.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	consumer := newTestConsumer(t, newFakeConsumerGroup(broker), deadLetters, handler)
+
+	broker.produce(0, mustMarshalEvent(t, uuid.NewString(), "Тверская", 3))
+
+	session := &fakeSession{ctx: context.Background(), broker: broker}
+	err := consumer.ConsumeClaim(session, &fakeClaim{broker: broker})
+
+	assert.ErrorIs(t, err, sarama.ErrOutOfBrokers)
+	assert.Empty(t, session.marked)
+	assert.Equal(t, int64(-1), broker.committedOffset())
+}
+
+func TestBasketConfirmedConsumer_ConsumeClaim_StopsBetweenAttempts(t *testing.T) {
+	broker := newFakeBroker()
+	handler := &recordingCreateOrderHandler{err: errors.New("db is down")}
+	consumer := newTestConsumer(t, newFakeConsumerGroup(broker), nil, handler)
+	consumer.initialBackoff = time.Hour
+	consumer.maxBackoff = time.Hour
+
+	broker.produce(0, mustMarshalEvent(t, uuid.NewString(), "Тверская", 3))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &fakeSession{ctx: ctx, broker: broker}
+	done := make(chan error)
+	go func() { done <- consumer.ConsumeClaim(session, &fakeClaim{broker: broker}) }()
+
+	assert.Eventually(t, func() bool {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		return handler.calls == 1
+	}, time.Second, time.Millisecond)
+	cancel()
+
+	require.NoError(t, <-done)
+	assert.Empty(t, session.marked)
+	assert.Equal(t, int64(-1), broker.committedOffset())
+}
+
+func TestBasketConfirmedConsumer_Backoff(t *testing.T) {
+	consumer := &BasketConfirmedConsumer{initialBackoff: 100 * time.Millisecond, maxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, consumer.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, consumer.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, consumer.backoff(4))
+	assert.Equal(t, time.Second, consumer.backoff(5))
+	assert.Equal(t, time.Second, consumer.backoff(50))
+}
+
+func TestBasketConfirmedConsumer_ConsumeClaim_DeadLettersMalformedMessages(t *testing.T) {
+	broker := newFakeBroker()
+	handler := &recordingCreateOrderHandler{}
+	deadLetters := mocks.NewSyncProducer(t, newProducerConfig())
+	deadLetters.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		value, err := message.Value.Encode()
+		require.NoError(t, err)
+		assert.Equal(t, []byte("not a protobuf"), value)
+		return nil
+	})
+	deadLetters.ExpectSendMessageAndSucceed()
+	consumer := newTestConsumer(t, newFakeConsumerGroup(broker), deadLetters, handler)
+
+	broker.produce(0, []byte("not a protobuf"))
+	broker.produce(1, mustMarshalEvent(t, "not-a-uuid", "Тверская", 3))
+	close(broker.messages)
+
+	session := &fakeSession{ctx: context.Background(), broker: broker}
+	require.NoError(t, consumer.ConsumeClaim(session, &fakeClaim{broker: broker}))
+
+	assert.Empty(t, handler.handled())
+	assert.Equal(t, []int64{0, 1}, session.marked)
+	assert.Equal(t, int64(1), broker.committedOffset())
+	require.NoError(t, deadLetters.Close())
+}
+
+func TestBasketConfirmedConsumer_ConsumeClaim_ContinuesProducerTrace(t *testing.T) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	broker := newFakeBroker()
+	handler := &recordingCreateOrderHandler{}
+	consumer := newTestConsumer(t, newFakeConsumerGroup(broker), nil, handler)
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "basket confirm")
+	span.End()
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+
+	message := &sarama.ConsumerMessage{Topic: testTopic, Value: mustMarshalEvent(t, uuid.NewString(), "Тверская", 3)}
+	for key, value := range carrier {
+		message.Headers = append(message.Headers, &sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	}
+	broker.messages <- message
+	close(broker.messages)
+
+	session := &fakeSession{ctx: context.Background(), broker: broker}
+	require.NoError(t, consumer.ConsumeClaim(session, &fakeClaim{broker: broker}))
+
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(handler.ctx).TraceID())
+}
+
+func TestBasketConfirmedConsumer_ConsumeClaim_CarriesCorrelationIds(t *testing.T) {
+	broker := newFakeBroker()
+	handler := &recordingCreateOrderHandler{}
+	consumer := newTestConsumer(t, newFakeConsumerGroup(broker), nil, handler)
+
+	broker.messages <- &sarama.ConsumerMessage{
+		Topic: testTopic,
+		Value: mustMarshalEvent(t, uuid.NewString(), "Тверская", 3),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte(correlation.CorrelationIDHeader), Value: []byte("checkout-1")},
+			{Key: []byte(correlation.MessageIDHeader), Value: []byte("message-1")},
+		},
+	}
+	broker.produce(1, mustMarshalEvent(t, uuid.NewString(), "Арбат", 1))
+	close(broker.messages)
+
+	session := &fakeSession{ctx: context.Background(), broker: broker}
+	require.NoError(t, consumer.ConsumeClaim(session, &fakeClaim{broker: broker}))
+
+	require.Len(t, handler.ctxs, 2)
+	assert.Equal(t, "checkout-1", correlation.CorrelationID(handler.ctxs[0]))
+	assert.Equal(t, "message-1", correlation.CausationID(handler.ctxs[0]))
+	assert.NotEmpty(t, correlation.CorrelationID(handler.ctxs[1]))
+	assert.Equal(t, testTopic+"/0/1", correlation.CausationID(handler.ctxs[1]))
+}
+
+func TestBasketConfirmedConsumer_StartAndClose(t *testing.T) {
+	broker := newFakeBroker()
+	group := newFakeConsumerGroup(broker)
+	handler := &recordingCreateOrderHandler{}
+	consumer := newTestConsumer(t, group, nil, handler)
+
+	consumer.Start(context.Background())
+	broker.produce(0, mustMarshalEvent(t, uuid.NewString(), "Тверская", 3))
+	broker.produce(1, mustMarshalEvent(t, uuid.NewString(), "Арбат", 1))
+
+	assert.Eventually(t, func() bool { return broker.committedOffset() == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, consumer.Close())
+
+	assert.Len(t, handler.handled(), 2)
+	assert.True(t, group.closed)
+}
+
+func TestMapBasketConfirmedToCreateOrderCommand(t *testing.T) {
+	basketID := uuid.New()
+
+	command, err := mapBasketConfirmedToCreateOrderCommand(&basketconfirmedpb.BasketConfirmedIntegrationEvent{
+		BasketId: basketID.String(),
+		Address:  &basketconfirmedpb.Address{Street: "Тверская"},
+		Volume:   5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, basketID, command.OrderID())
+	assert.Equal(t, "Тверская", command.Street())
+	assert.Equal(t, 5, command.Volume())
+
+	tests := map[string]*basketconfirmedpb.BasketConfirmedIntegrationEvent{
+		"nil event":       nil,
+		"invalid basket":  {BasketId: "42", Address: &basketconfirmedpb.Address{Street: "Тверская"}, Volume: 5},
+		"missing address": {BasketId: basketID.String(), Volume: 5},
+		"zero volume":     {BasketId: basketID.String(), Address: &basketconfirmedpb.Address{Street: "Тверская"}},
+	}
+	for name, event := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := mapBasketConfirmedToCreateOrderCommand(event)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package kafka
+
+import (
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/generated/queues/basketconfirmedpb"
+	"delivery/internal/pkg/errs"
+
+	"github.com/google/uuid"
+)
+
+// mapBasketConfirmedToCreateOrderCommand — антикоррупционный слой между
+// контрактом сервиса Basket и нашей командой. Идентификатор корзины становится
+// идентификатором заказа, из адреса нам нужна только улица.
+func mapBasketConfirmedToCreateOrderCommand(event *basketconfirmedpb.BasketConfirmedIntegrationEvent) (commands.CreateOrderCommand, error) {
+	if event == nil {
+		return commands.CreateOrderCommand{}, errs.NewValueIsRequiredError("event")
+	}
+
+	orderID, err := uuid.Parse(event.GetBasketId())
+	if err != nil {
+		return commands.CreateOrderCommand{}, errs.NewValueIsInvalidErrorWithCause("basketId", err)
+	}
+
+	return commands.NewCreateOrderCommand(orderID, event.GetAddress().GetStreet(), int(event.GetVolume()))
+}
@@ -0,0 +1,131 @@
+package geo
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/ports"
+	"delivery/internal/generated/clients/geosrv"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/health"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// defaultTimeout применяется, только если вызывающий не задал свой дедлайн
+const defaultTimeout = 5 * time.Second
+
+var _ ports.GeoClient = &Client{}
+var _ health.Checker = &Client{}
+
+type Client struct {
+	conn     *grpc.ClientConn
+	pbClient geosrv.GeoClient
+	grid     kernel.Grid
+	timeout  time.Duration
+	ownsConn bool
+}
+
+// NewClient подключается к сервису Geo. Координаты из ответов проверяются по
+// сетке города: точка вне сетки считается ошибкой сервиса Geo.
+func NewClient(host string, grid kernel.Grid) (*Client, error) {
+	if host == "" {
+		return nil, errs.NewValueIsRequiredError("host")
+	}
+
+	conn, err := grpc.NewClient(host,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to geo service: %w", err)
+	}
+
+	client, err := NewClientWithConn(conn, grid)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	client.ownsConn = true
+	return client, nil
+}
+
+// NewClientWithConn создает клиент поверх готового соединения. Соединение
+// остается во владении вызывающего и не закрывается в Close.
+func NewClientWithConn(conn *grpc.ClientConn, grid kernel.Grid) (*Client, error) {
+	if conn == nil {
+		return nil, errs.NewValueIsRequiredError("conn")
+	}
+	if grid.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("grid")
+	}
+
+	return &Client{
+		conn:     conn,
+		pbClient: geosrv.NewGeoClient(conn),
+		grid:     grid,
+		timeout:  defaultTimeout,
+	}, nil
+}
+
+func (c *Client) GetGeolocation(ctx context.Context, street string) (kernel.Location, error) {
+	if street == "" {
+		return kernel.Location{}, errs.NewValueIsRequiredError("street")
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	reply, err := c.pbClient.GetGeolocation(ctx, &geosrv.GetGeolocationRequest{Street: street})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound, codes.InvalidArgument:
+			return kernel.Location{}, errs.NewValueIsInvalidErrorWithCause("street", err)
+		default:
+			return kernel.Location{}, fmt.Errorf("failed to get geolocation: %w", err)
+		}
+	}
+
+	location, err := kernel.NewLocation(c.grid, int(reply.GetLocation().GetX()), int(reply.GetLocation().GetY()))
+	if err != nil {
+		return kernel.Location{}, fmt.Errorf("geo service returned invalid location: %w", err)
+	}
+	return location, nil
+}
+
+func (c *Client) Close() error {
+	if !c.ownsConn {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// Check сообщает, установлено ли соединение с сервисом Geo. Простаивающее
+// соединение будится и проверка ждет его готовности до дедлайна контекста.
+func (c *Client) Check(ctx context.Context) (map[string]any, error) {
+	state := c.conn.GetState()
+	if state == connectivity.Idle {
+		c.conn.Connect()
+	}
+
+	for state != connectivity.Ready {
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			return map[string]any{"state": state.String()}, fmt.Errorf("geo connection is %s", state)
+		}
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return map[string]any{"state": state.String()}, ctx.Err()
+		}
+		state = c.conn.GetState()
+	}
+
+	return map[string]any{"state": state.String()}, nil
+}
@@ -0,0 +1,156 @@
+package geo
+
+import (
+	"context"
+	"delivery/internal/adapters/out/grpc/geo/geofake"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/pkg/errs"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func setupClient(t *testing.T, server *geofake.Server) *Client {
+	t.Helper()
+	return setupClientOnGrid(t, server, kernel.DefaultGrid())
+}
+
+func setupClientOnGrid(t *testing.T, server *geofake.Server, grid kernel.Grid) *Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := server.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	client, err := NewClientWithConn(conn, grid)
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClient_Validation(t *testing.T) {
+	_, err := NewClient("", kernel.DefaultGrid())
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = NewClient("localhost:5004", kernel.Grid{})
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = NewClientWithConn(nil, kernel.DefaultGrid())
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+}
+
+func TestClient_GetGeolocation_PilotCityGrid(t *testing.T) {
+	grid, err := kernel.NewGridOfSize(200, 150)
+	require.NoError(t, err)
+	client := setupClientOnGrid(t, geofake.NewServer(
+		geofake.WithGrid(200, 150),
+		geofake.WithStreet("Окраинная", 180, 140),
+	), grid)
+
+	location, err := client.GetGeolocation(context.Background(), "Окраинная")
+	require.NoError(t, err)
+	assert.Equal(t, 180, location.X())
+	assert.Equal(t, 140, location.Y())
+
+	for _, street := range []string{"Несуществующая", "Тестировочная", "Айтишная", "Бажная"} {
+		location, err := client.GetGeolocation(context.Background(), street)
+		require.NoError(t, err)
+		assert.True(t, grid.Contains(location))
+	}
+}
+
+func TestClient_GetGeolocation_KnownStreet(t *testing.T) {
+	client := setupClient(t, geofake.NewServer(geofake.WithStreet("Тестировочная", 3, 7)))
+
+	location, err := client.GetGeolocation(context.Background(), "Тестировочная")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, location.X())
+	assert.Equal(t, 7, location.Y())
+}
+
+func TestClient_GetGeolocation_UnknownStreetIsStable(t *testing.T) {
+	client := setupClient(t, geofake.NewServer())
+
+	first, err := client.GetGeolocation(context.Background(), "Несуществующая")
+	require.NoError(t, err)
+	second, err := client.GetGeolocation(context.Background(), "Несуществующая")
+	require.NoError(t, err)
+
+	assert.True(t, first.Equals(second))
+}
+
+func TestClient_GetGeolocation_EmptyStreet(t *testing.T) {
+	client := setupClient(t, geofake.NewServer())
+
+	_, err := client.GetGeolocation(context.Background(), "")
+
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+}
+
+func TestClient_GetGeolocation_InvalidLocationFromServer(t *testing.T) {
+	client := setupClient(t, geofake.NewServer(geofake.WithStreet("За МКАДом", 100, 100)))
+
+	_, err := client.GetGeolocation(context.Background(), "За МКАДом")
+
+	assert.Error(t, err)
+}
+
+func TestClient_GetGeolocation_RespectsContextDeadline(t *testing.T) {
+	client := setupClient(t, geofake.NewServer(geofake.WithDelay(time.Second)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetGeolocation(ctx, "Тестировочная")
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestClient_GetGeolocation_AppliesDefaultTimeout(t *testing.T) {
+	client := setupClient(t, geofake.NewServer(geofake.WithDelay(time.Second)))
+	client.timeout = 20 * time.Millisecond
+
+	_, err := client.GetGeolocation(context.Background(), "Тестировочная")
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestClient_Check(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		client := setupClient(t, geofake.NewServer())
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		details, err := client.Check(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, "READY", details["state"])
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		client, err := NewClient("127.0.0.1:1", kernel.DefaultGrid())
+		require.NoError(t, err)
+		defer func() { _ = client.Close() }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err = client.Check(ctx)
+
+		assert.Error(t, err)
+	})
+}
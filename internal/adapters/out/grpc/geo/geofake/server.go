@@ -0,0 +1,113 @@
+package geofake
+
+import (
+	"context"
+	"delivery/internal/generated/clients/geosrv"
+	"hash/fnv"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Размер карты по умолчанию совпадает с kernel.DefaultGrid
+const (
+	defaultGridWidth  = 10
+	defaultGridHeight = 10
+)
+
+var _ geosrv.GeoServer = &Server{}
+
+// Server — упрощенная замена сервиса Geo для тестов и локальной разработки.
+// Известные улицы возвращают заданные координаты, остальные — координаты,
+// вычисленные из названия: одна и та же улица всегда находится в одной точке.
+type Server struct {
+	geosrv.UnimplementedGeoServer
+
+	streets    map[string]*geosrv.Location
+	delay      time.Duration
+	gridWidth  uint32
+	gridHeight uint32
+}
+
+type Option func(*Server)
+
+// WithStreet задает координаты улицы
+func WithStreet(street string, x int32, y int32) Option {
+	return func(s *Server) {
+		s.streets[street] = &geosrv.Location{X: x, Y: y}
+	}
+}
+
+// WithDelay замедляет каждый ответ, чтобы проверять дедлайны клиента
+func WithDelay(delay time.Duration) Option {
+	return func(s *Server) {
+		s.delay = delay
+	}
+}
+
+// WithGrid задает размер карты, на которую попадают неизвестные улицы.
+// Координаты начинаются с 1, как в kernel.NewGridOfSize.
+func WithGrid(width uint32, height uint32) Option {
+	return func(s *Server) {
+		s.gridWidth = max(width, 1)
+		s.gridHeight = max(height, 1)
+	}
+}
+
+func NewServer(options ...Option) *Server {
+	server := &Server{
+		streets:    make(map[string]*geosrv.Location),
+		gridWidth:  defaultGridWidth,
+		gridHeight: defaultGridHeight,
+	}
+	for _, option := range options {
+		option(server)
+	}
+	return server
+}
+
+func (s *Server) GetGeolocation(ctx context.Context, request *geosrv.GetGeolocationRequest) (*geosrv.GetGeolocationReply, error) {
+	if request.GetStreet() == "" {
+		return nil, status.Error(codes.InvalidArgument, "street is required")
+	}
+
+	if s.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-time.After(s.delay):
+		}
+	}
+
+	location, ok := s.streets[request.GetStreet()]
+	if !ok {
+		location = s.locationFromStreet(request.GetStreet())
+	}
+	return &geosrv.GetGeolocationReply{Location: location}, nil
+}
+
+// Serve регистрирует сервер на новом grpc.Server и обслуживает listener в
+// отдельной горутине. Остановка — через GracefulStop или Stop.
+func (s *Server) Serve(listener net.Listener) *grpc.Server {
+	grpcServer := grpc.NewServer()
+	geosrv.RegisterGeoServer(grpcServer, s)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	return grpcServer
+}
+
+func (s *Server) locationFromStreet(street string) *geosrv.Location {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(street))
+	sum := hash.Sum32()
+
+	return &geosrv.Location{
+		X: int32(sum%s.gridWidth) + 1,
+		Y: int32(sum/s.gridWidth%s.gridHeight) + 1,
+	}
+}
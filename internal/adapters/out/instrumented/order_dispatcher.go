@@ -0,0 +1,38 @@
+package instrumented
+
+import (
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/core/domain/services"
+	"delivery/internal/pkg/metrics"
+	"errors"
+	"time"
+)
+
+var _ services.OrderDispatcher = &orderDispatcher{}
+
+type orderDispatcher struct {
+	next services.OrderDispatcher
+}
+
+// NewOrderDispatcher оборачивает диспетчер, не меняя его поведения:
+// считает исходы Dispatch и время выбора курьера
+func NewOrderDispatcher(next services.OrderDispatcher) services.OrderDispatcher {
+	return &orderDispatcher{next: next}
+}
+
+func (d *orderDispatcher) Dispatch(order *order.Order, couriers []*courier.Courier) (*courier.Courier, error) {
+	start := time.Now()
+	result, err := d.next.Dispatch(order, couriers)
+
+	outcome := metrics.DispatchAssigned
+	switch {
+	case errors.Is(err, services.ErrCourierNotFound):
+		outcome = metrics.DispatchNoCourier
+	case err != nil:
+		outcome = metrics.DispatchError
+	}
+	metrics.ObserveDispatch(outcome, time.Since(start))
+
+	return result, err
+}
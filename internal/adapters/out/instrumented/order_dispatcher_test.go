@@ -0,0 +1,61 @@
+package instrumented
+
+import (
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/core/domain/services"
+	"delivery/internal/pkg/metrics"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubOrderDispatcher struct {
+	err error
+}
+
+func (d stubOrderDispatcher) Dispatch(*order.Order, []*courier.Courier) (*courier.Courier, error) {
+	return nil, d.err
+}
+
+// dispatchCount читает delivery_dispatch_total с нужным исходом из реестра
+func dispatchCount(t *testing.T, outcome string) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "delivery_dispatch_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "outcome" && label.GetValue() == outcome {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestOrderDispatcher(t *testing.T) {
+	tests := map[string]error{
+		metrics.DispatchAssigned:  nil,
+		metrics.DispatchNoCourier: services.ErrCourierNotFound,
+		metrics.DispatchError:     errors.New("order is required"),
+	}
+
+	for outcome, dispatchErr := range tests {
+		t.Run(outcome, func(t *testing.T) {
+			before := dispatchCount(t, outcome)
+
+			_, err := NewOrderDispatcher(stubOrderDispatcher{err: dispatchErr}).Dispatch(nil, nil)
+
+			assert.Equal(t, dispatchErr, err)
+			assert.Equal(t, before+1, dispatchCount(t, outcome))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/health"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+const defaultDialTimeout = 5 * time.Second
+
+var _ health.Checker = &HealthChecker{}
+
+// HealthChecker подключается к кластеру и читает метаданные. Producer и
+// consumer работают с теми же брокерами, поэтому одна проверка покрывает оба.
+// Отсутствие топика не считается отказом, но видно в details. Результат
+// хранится ttl, чтобы частые пробы не подключались к брокерам каждый раз.
+type HealthChecker struct {
+	brokers []string
+	topics  []string
+	ttl     time.Duration
+	clk     clock.Clock
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	details   map[string]any
+	err       error
+}
+
+func NewHealthChecker(brokers []string, topics []string, ttl time.Duration, clk clock.Clock) (*HealthChecker, error) {
+	if len(brokers) == 0 {
+		return nil, errs.NewValueIsRequiredError("brokers")
+	}
+	if ttl < 0 {
+		return nil, errs.NewValueIsInvalidError("ttl")
+	}
+	if clk == nil {
+		return nil, errs.NewValueIsRequiredError("clk")
+	}
+
+	return &HealthChecker{
+		brokers: brokers,
+		topics:  topics,
+		ttl:     ttl,
+		clk:     clk,
+	}, nil
+}
+
+// Check отдает сохраненный результат, пока он не старше ttl. Одновременные
+// пробы ждут одну и ту же проверку, а не подключаются параллельно.
+func (c *HealthChecker) Check(ctx context.Context) (map[string]any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clk.Now()
+	if !c.checkedAt.IsZero() && now.Sub(c.checkedAt) < c.ttl {
+		return c.details, c.err
+	}
+
+	details, err := c.check(ctx)
+	if ctx.Err() == nil {
+		c.checkedAt, c.details, c.err = now, details, err
+	}
+	return details, err
+}
+
+func (c *HealthChecker) check(ctx context.Context) (map[string]any, error) {
+	config := sarama.NewConfig()
+	config.Net.DialTimeout = defaultDialTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		config.Net.DialTimeout = time.Until(deadline)
+	}
+	config.Metadata.Retry.Max = 0
+
+	client, err := sarama.NewClient(c.brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	existing, err := client.Topics()
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make(map[string]bool, len(c.topics))
+	for _, topic := range c.topics {
+		topics[topic] = slices.Contains(existing, topic)
+	}
+
+	return map[string]any{
+		"brokers": len(client.Brokers()),
+		"topics":  topics,
+	}, nil
+}
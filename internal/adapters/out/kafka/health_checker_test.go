@@ -0,0 +1,86 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/pkg/clock"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHealthChecker(t *testing.T) {
+	_, err := NewHealthChecker(nil, nil, time.Second, clock.System())
+	assert.Error(t, err)
+
+	_, err = NewHealthChecker([]string{"localhost:9092"}, nil, -time.Second, clock.System())
+	assert.Error(t, err)
+
+	_, err = NewHealthChecker([]string{"localhost:9092"}, nil, time.Second, nil)
+	assert.Error(t, err)
+}
+
+func TestHealthChecker_Check(t *testing.T) {
+	t.Run("reports brokers and topics", func(t *testing.T) {
+		broker := sarama.NewMockBroker(t, 1)
+		defer broker.Close()
+		broker.SetHandlerByMap(map[string]sarama.MockResponse{
+			"MetadataRequest": sarama.NewMockMetadataResponse(t).
+				SetBroker(broker.Addr(), broker.BrokerID()).
+				SetLeader(testTopic, 0, broker.BrokerID()),
+		})
+
+		checker, err := NewHealthChecker([]string{broker.Addr()}, []string{testTopic, "missing.topic"}, 0, clock.System())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		details, err := checker.Check(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, details["brokers"])
+		assert.Equal(t, map[string]bool{testTopic: true, "missing.topic": false}, details["topics"])
+	})
+
+	t.Run("unreachable brokers", func(t *testing.T) {
+		checker, err := NewHealthChecker([]string{"127.0.0.1:1"}, nil, 0, clock.System())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err = checker.Check(ctx)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("caches result for ttl", func(t *testing.T) {
+		broker := sarama.NewMockBroker(t, 1)
+		defer broker.Close()
+		broker.SetHandlerByMap(map[string]sarama.MockResponse{
+			"MetadataRequest": sarama.NewMockMetadataResponse(t).
+				SetBroker(broker.Addr(), broker.BrokerID()),
+		})
+
+		now := clock.NewFake(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+		checker, err := NewHealthChecker([]string{broker.Addr()}, nil, 10*time.Second, now)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err = checker.Check(ctx)
+		require.NoError(t, err)
+		requests := len(broker.History())
+
+		now.Advance(5 * time.Second)
+		_, err = checker.Check(ctx)
+		require.NoError(t, err)
+		assert.Len(t, broker.History(), requests)
+
+		now.Advance(5 * time.Second)
+		_, err = checker.Check(ctx)
+		require.NoError(t, err)
+		assert.Greater(t, len(broker.History()), requests)
+	})
+}
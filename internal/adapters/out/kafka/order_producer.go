@@ -0,0 +1,148 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/generated/queues/orderstatuschangedpb"
+	"delivery/internal/pkg/correlation"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/metrics"
+	"delivery/internal/pkg/tracing"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ ports.OrderProducer = &OrderProducer{}
+
+// orderStatusEvent — доменное событие заказа, после которого заказ находится в
+// новом статусе. Только такие события уходят в топик order.status.changed.
+type orderStatusEvent interface {
+	ddd.DomainEvent
+	GetOrderID() uuid.UUID
+	GetOrderStatus() string
+}
+
+// OrderProducer публикует смену статуса заказа в Kafka. Ключом сообщения служит
+// идентификатор заказа: все события одного заказа попадают в одну партицию и
+// читаются потребителями в порядке публикации.
+type OrderProducer struct {
+	topic    string
+	producer sarama.SyncProducer
+}
+
+func NewOrderProducer(brokers []string, topic string) (*OrderProducer, error) {
+	if len(brokers) == 0 {
+		return nil, errs.NewValueIsRequiredError("brokers")
+	}
+
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	config.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+
+	orderProducer, err := newOrderProducer(producer, topic)
+	if err != nil {
+		_ = producer.Close()
+		return nil, err
+	}
+	return orderProducer, nil
+}
+
+func newOrderProducer(producer sarama.SyncProducer, topic string) (*OrderProducer, error) {
+	if producer == nil {
+		return nil, errs.NewValueIsRequiredError("producer")
+	}
+	if topic == "" {
+		return nil, errs.NewValueIsRequiredError("topic")
+	}
+
+	return &OrderProducer{
+		topic:    topic,
+		producer: producer,
+	}, nil
+}
+
+func (p *OrderProducer) Publish(ctx context.Context, domainEvent ddd.DomainEvent) (err error) {
+	ctx, span := tracing.Start(ctx, p.topic+" publish", trace.WithSpanKind(trace.SpanKindProducer))
+	defer func() { tracing.End(span, err) }()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	event, ok := domainEvent.(orderStatusEvent)
+	if !ok {
+		return fmt.Errorf("unsupported order event %T", domainEvent)
+	}
+
+	integrationEvent, err := mapOrderStatusEventToIntegrationEvent(event)
+	if err != nil {
+		return err
+	}
+
+	value, err := proto.Marshal(integrationEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", event.GetName(), err)
+	}
+
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   p.topic,
+		Key:     sarama.StringEncoder(event.GetOrderID().String()),
+		Value:   sarama.ByteEncoder(value),
+		Headers: headers(ctx, event),
+	})
+	metrics.ObserveKafkaProduced(p.topic, err)
+	if err != nil {
+		return fmt.Errorf("failed to publish %s: %w", event.GetName(), err)
+	}
+	return nil
+}
+
+func (p *OrderProducer) Close() error {
+	return p.producer.Close()
+}
+
+// headers передает потребителям идентификатор сообщения, correlation и
+// causation ID, а также контекст трассировки (traceparent, tracestate)
+func headers(ctx context.Context, event ddd.DomainEvent) []sarama.RecordHeader {
+	values := map[string]string{
+		correlation.MessageIDHeader:     event.GetID().String(),
+		correlation.CorrelationIDHeader: correlation.CorrelationID(ctx),
+		correlation.CausationIDHeader:   correlation.CausationID(ctx),
+	}
+	for key, value := range tracing.Inject(ctx) {
+		values[key] = value
+	}
+
+	var headers []sarama.RecordHeader
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if values[key] != "" {
+			headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(values[key])})
+		}
+	}
+	return headers
+}
+
+func mapOrderStatusEventToIntegrationEvent(event orderStatusEvent) (*orderstatuschangedpb.OrderStatusChangedIntegrationEvent, error) {
+	status, ok := orderstatuschangedpb.OrderStatus_value[event.GetOrderStatus()]
+	if !ok {
+		return nil, errs.NewValueIsInvalidError("orderStatus")
+	}
+
+	return &orderstatuschangedpb.OrderStatusChangedIntegrationEvent{
+		OrderId:     event.GetOrderID().String(),
+		OrderStatus: orderstatuschangedpb.OrderStatus(status),
+	}, nil
+}
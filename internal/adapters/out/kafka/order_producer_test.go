@@ -0,0 +1,136 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/generated/queues/orderstatuschangedpb"
+	"delivery/internal/pkg/correlation"
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+const testTopic = "order.status.changed"
+
+type testOrderEvent struct {
+	ID      uuid.UUID
+	OrderID uuid.UUID
+	Status  string
+}
+
+func (e *testOrderEvent) GetID() uuid.UUID       { return e.ID }
+func (e *testOrderEvent) GetName() string        { return "testOrderEvent" }
+func (e *testOrderEvent) GetOrderID() uuid.UUID  { return e.OrderID }
+func (e *testOrderEvent) GetOrderStatus() string { return e.Status }
+
+type unsupportedEvent struct{}
+
+func (e *unsupportedEvent) GetID() uuid.UUID { return uuid.Nil }
+func (e *unsupportedEvent) GetName() string  { return "unsupportedEvent" }
+
+func newTestConfig() *sarama.Config {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	return config
+}
+
+func TestNewOrderProducer_Validation(t *testing.T) {
+	_, err := newOrderProducer(nil, testTopic)
+	assert.Error(t, err)
+
+	_, err = newOrderProducer(mocks.NewSyncProducer(t, newTestConfig()), "")
+	assert.Error(t, err)
+
+	_, err = NewOrderProducer(nil, testTopic)
+	assert.Error(t, err)
+}
+
+func TestOrderProducer_Publish_KeysMessageByOrderID(t *testing.T) {
+	event := &testOrderEvent{ID: uuid.New(), OrderID: uuid.New(), Status: "Completed"}
+
+	mockProducer := mocks.NewSyncProducer(t, newTestConfig())
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		assert.Equal(t, testTopic, message.Topic)
+
+		key, err := message.Key.Encode()
+		require.NoError(t, err)
+		assert.Equal(t, event.OrderID.String(), string(key))
+
+		value, err := message.Value.Encode()
+		require.NoError(t, err)
+		integrationEvent := &orderstatuschangedpb.OrderStatusChangedIntegrationEvent{}
+		require.NoError(t, proto.Unmarshal(value, integrationEvent))
+		assert.Equal(t, event.OrderID.String(), integrationEvent.GetOrderId())
+		assert.Equal(t, orderstatuschangedpb.OrderStatus_Completed, integrationEvent.GetOrderStatus())
+		return nil
+	})
+
+	producer, err := newOrderProducer(mockProducer, testTopic)
+	require.NoError(t, err)
+
+	require.NoError(t, producer.Publish(context.Background(), event))
+	require.NoError(t, producer.Close())
+}
+
+func TestOrderProducer_Publish_PropagatesTraceAndCorrelation(t *testing.T) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	ctx := correlation.WithCausationID(correlation.WithCorrelationID(context.Background(), "checkout-1"), "event-1")
+	ctx, span := otel.Tracer("test").Start(ctx, "outbox publish")
+	defer span.End()
+
+	mockProducer := mocks.NewSyncProducer(t, newTestConfig())
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		headers := make(map[string]string)
+		for _, header := range message.Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		require.Contains(t, headers, "traceparent")
+		assert.Equal(t, "checkout-1", headers[correlation.CorrelationIDHeader])
+		assert.Equal(t, "event-1", headers[correlation.CausationIDHeader])
+
+		sent := trace.SpanContextFromContext(
+			propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier(headers)))
+		assert.Equal(t, span.SpanContext().TraceID(), sent.TraceID())
+		return nil
+	})
+
+	producer, err := newOrderProducer(mockProducer, testTopic)
+	require.NoError(t, err)
+
+	event := &testOrderEvent{ID: uuid.New(), OrderID: uuid.New(), Status: "Completed"}
+	require.NoError(t, producer.Publish(ctx, event))
+	require.NoError(t, producer.Close())
+}
+
+func TestOrderProducer_Publish_ReturnsSendError(t *testing.T) {
+	sendErr := errors.New("broker is down")
+	mockProducer := mocks.NewSyncProducer(t, newTestConfig())
+	mockProducer.ExpectSendMessageAndFail(sendErr)
+
+	producer, err := newOrderProducer(mockProducer, testTopic)
+	require.NoError(t, err)
+
+	err = producer.Publish(context.Background(), &testOrderEvent{ID: uuid.New(), OrderID: uuid.New(), Status: "Created"})
+	assert.ErrorIs(t, err, sendErr)
+	require.NoError(t, producer.Close())
+}
+
+func TestOrderProducer_Publish_RejectsUnknownEvents(t *testing.T) {
+	producer, err := newOrderProducer(mocks.NewSyncProducer(t, newTestConfig()), testTopic)
+	require.NoError(t, err)
+
+	assert.Error(t, producer.Publish(context.Background(), &unsupportedEvent{}))
+	assert.Error(t, producer.Publish(context.Background(),
+		&testOrderEvent{ID: uuid.New(), OrderID: uuid.New(), Status: "Lost"}))
+	require.NoError(t, producer.Close())
+}
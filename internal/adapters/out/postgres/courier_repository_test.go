@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/errs"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCourierRepository(t *testing.T) {
+	ctx, db := setupTest(t)
+
+	uow, err := NewUnitOfWork(db, clock.System())
+	require.NoError(t, err)
+	repository := uow.CourierRepository()
+
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 1, 1)
+	require.NoError(t, err)
+
+	busy, err := courier.NewCourier("Вело", kernel.Bicycle, 2, location)
+	require.NoError(t, err)
+	require.NoError(t, busy.AddStoragePlace("Вело-Багажник", 30))
+	free, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, location)
+	require.NoError(t, err)
+
+	require.NoError(t, repository.Add(ctx, busy))
+	require.NoError(t, repository.Add(ctx, free))
+
+	t.Run("get", func(t *testing.T) {
+		restored, err := repository.Get(ctx, busy.ID())
+		require.NoError(t, err)
+		assert.Equal(t, busy.ID(), restored.ID())
+		assert.Equal(t, busy.Name(), restored.Name())
+		assert.Equal(t, busy.Transport(), restored.Transport())
+		assert.Equal(t, busy.Speed(), restored.Speed())
+		assert.Equal(t, busy.Location(), restored.Location())
+		assert.Len(t, restored.Places(), 2)
+	})
+
+	t.Run("get unknown", func(t *testing.T) {
+		_, err := repository.Get(ctx, uuid.New())
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+	})
+
+	t.Run("update storage places and get all free", func(t *testing.T) {
+		o, err := order.NewOrder(uuid.New(), location, 20)
+		require.NoError(t, err)
+		require.NoError(t, busy.TakeOrder(o))
+		require.NoError(t, repository.Update(ctx, busy))
+
+		restored, err := repository.Get(ctx, busy.ID())
+		require.NoError(t, err)
+		assert.False(t, restored.CanTakeOrder(o))
+
+		couriers, err := repository.GetAllFree(ctx)
+		require.NoError(t, err)
+		require.Len(t, couriers, 1)
+		assert.Equal(t, free.ID(), couriers[0].ID())
+	})
+
+	t.Run("update deletes removed storage places", func(t *testing.T) {
+		before, err := repository.Get(ctx, free.ID())
+		require.NoError(t, err)
+		require.NoError(t, free.AddStoragePlace("Рюкзак", 20))
+		require.NoError(t, repository.Update(ctx, free))
+
+		// Тот же курьер без добавленного рюкзака
+		trimmed := courier.RestoreCourier(free.ID(), free.Name(), free.Transport(), free.Speed(), free.Location(), free.MovementBudget(), before.Places(), free.Version())
+		require.NoError(t, repository.Update(ctx, trimmed))
+
+		restored, err := repository.Get(ctx, free.ID())
+		require.NoError(t, err)
+		assert.Len(t, restored.Places(), len(before.Places()))
+		var count int64
+		require.NoError(t, db.Table("storage_places").Where("courier_id = ?", free.ID()).Count(&count).Error)
+		assert.Equal(t, int64(len(before.Places())), count)
+	})
+
+	t.Run("rolled back update keeps version", func(t *testing.T) {
+		restored, err := repository.Get(ctx, busy.ID())
+		require.NoError(t, err)
+		version := restored.Version()
+
+		require.NoError(t, uow.Begin(ctx))
+		require.NoError(t, repository.Update(ctx, restored))
+		require.NoError(t, uow.Rollback())
+		assert.Equal(t, version, restored.Version())
+
+		// Повтор проходит проверку версии
+		require.NoError(t, repository.Update(ctx, restored))
+		assert.Equal(t, version+1, restored.Version())
+	})
+
+	t.Run("get keeps storage places in the order they were added", func(t *testing.T) {
+		packed, err := courier.NewCourier("Авто", kernel.Car, 3, location)
+		require.NoError(t, err)
+		require.NoError(t, packed.AddStoragePlace("Багажник", 40))
+		require.NoError(t, packed.AddStoragePlace("Бардачок", 1))
+		require.NoError(t, repository.Add(ctx, packed))
+
+		restored, err := repository.Get(ctx, packed.ID())
+		require.NoError(t, err)
+		require.Len(t, restored.Places(), len(packed.Places()))
+		for i, place := range packed.Places() {
+			assert.Equal(t, place.ID(), restored.Places()[i].ID())
+		}
+	})
+}
+
+func TestCourierRepository_OptimisticConcurrency(t *testing.T) {
+	ctx, db := setupTest(t)
+
+	uow, err := NewUnitOfWork(db, clock.System())
+	require.NoError(t, err)
+	repository := uow.CourierRepository()
+
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 1, 1)
+	require.NoError(t, err)
+
+	aggregate, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, location)
+	require.NoError(t, err)
+	require.NoError(t, repository.Add(ctx, aggregate))
+
+	first, err := repository.Get(ctx, aggregate.ID())
+	require.NoError(t, err)
+	second, err := repository.Get(ctx, aggregate.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, first.AddStoragePlace("Рюкзак", 20))
+	require.NoError(t, repository.Update(ctx, first))
+
+	require.NoError(t, second.AddStoragePlace("Пакет", 5))
+	err = repository.Update(ctx, second)
+	assert.ErrorIs(t, err, errs.ErrVersionIsInvalid)
+
+	restored, err := repository.Get(ctx, aggregate.ID())
+	require.NoError(t, err)
+	assert.Len(t, restored.Places(), 2)
+	assert.Equal(t, 2, restored.Version())
+}
@@ -0,0 +1,39 @@
+package courierrepo
+
+import (
+	"github.com/google/uuid"
+)
+
+type CourierDTO struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name           string    `gorm:"type:varchar(255)"`
+	Transport      string    `gorm:"type:varchar(16)"`
+	Speed          int
+	Location       LocationDTO `gorm:"embedded;embeddedPrefix:location_"`
+	MovementBudget float64
+	StoragePlaces  []*StoragePlaceDTO `gorm:"foreignKey:CourierID;constraint:OnDelete:CASCADE;"`
+	Version        int                `gorm:"not null;default:0"`
+}
+
+type StoragePlaceDTO struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name        string    `gorm:"type:varchar(255)"`
+	TotalVolume int
+	OrderID     *uuid.UUID `gorm:"type:uuid"`
+	CourierID   uuid.UUID  `gorm:"type:uuid;index"`
+	// Position — порядок места у курьера, в котором его добавил агрегат
+	Position int
+}
+
+type LocationDTO struct {
+	X int
+	Y int
+}
+
+func (CourierDTO) TableName() string {
+	return "couriers"
+}
+
+func (StoragePlaceDTO) TableName() string {
+	return "storage_places"
+}
@@ -0,0 +1,53 @@
+package courierrepo
+
+import (
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+)
+
+func DomainToDTO(aggregate *courier.Courier) CourierDTO {
+	storagePlaces := make([]*StoragePlaceDTO, 0, len(aggregate.Places()))
+	for i, place := range aggregate.Places() {
+		storagePlaces = append(storagePlaces, &StoragePlaceDTO{
+			ID:          place.ID(),
+			Name:        place.Name(),
+			TotalVolume: place.TotalVolume(),
+			OrderID:     place.OrderID(),
+			CourierID:   aggregate.ID(),
+			Position:    i,
+		})
+	}
+
+	return CourierDTO{
+		ID:        aggregate.ID(),
+		Name:      aggregate.Name(),
+		Transport: aggregate.Transport().String(),
+		Speed:     aggregate.Speed(),
+		Location: LocationDTO{
+			X: aggregate.Location().X(),
+			Y: aggregate.Location().Y(),
+		},
+		MovementBudget: aggregate.MovementBudget(),
+		StoragePlaces:  storagePlaces,
+		Version:        aggregate.Version(),
+	}
+}
+
+func DtoToDomain(dto CourierDTO) (*courier.Courier, error) {
+	location, err := kernel.RestoreLocation(dto.Location.X, dto.Location.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := kernel.ParseTransport(dto.Transport)
+	if err != nil {
+		return nil, err
+	}
+
+	places := make([]*courier.StoragePlace, 0, len(dto.StoragePlaces))
+	for _, place := range dto.StoragePlaces {
+		places = append(places, courier.RestoreStoragePlace(place.ID, place.Name, place.TotalVolume, place.OrderID))
+	}
+
+	return courier.RestoreCourier(dto.ID, dto.Name, transport, dto.Speed, location, dto.MovementBudget, places, dto.Version), nil
+}
@@ -0,0 +1,72 @@
+package courierrepo
+
+import (
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/errs"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapper_RoundTrip(t *testing.T) {
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 3, 7)
+	require.NoError(t, err)
+
+	aggregate, err := courier.NewCourier("Вело", kernel.Bicycle, 2, location)
+	require.NoError(t, err)
+	require.NoError(t, aggregate.AddStoragePlace("Вело-Багажник", 30))
+
+	o, err := order.NewOrder(uuid.New(), location, 5)
+	require.NoError(t, err)
+	require.NoError(t, aggregate.TakeOrder(o))
+	// Клетка в пробке дороже скорости: курьер копит запас хода
+	target, err := kernel.NewLocation(kernel.DefaultGrid(), 3, 8)
+	require.NoError(t, err)
+	jam, err := kernel.NewTrafficZone("jam", target, target, map[kernel.Transport]float64{kernel.Bicycle: 5})
+	require.NoError(t, err)
+	costs, err := kernel.NewCostMap([]kernel.TrafficZone{jam})
+	require.NoError(t, err)
+	cityMap, err := kernel.NewCityMap(kernel.DefaultGrid(), nil)
+	require.NoError(t, err)
+	require.NoError(t, aggregate.Move(target, cityMap, costs))
+	require.Equal(t, 2.0, aggregate.MovementBudget())
+
+	dto := DomainToDTO(aggregate)
+	for i, place := range dto.StoragePlaces {
+		assert.Equal(t, aggregate.ID(), place.CourierID)
+		assert.Equal(t, i, place.Position)
+	}
+
+	restored, err := DtoToDomain(dto)
+	require.NoError(t, err)
+
+	assert.Equal(t, aggregate.ID(), restored.ID())
+	assert.Equal(t, aggregate.Name(), restored.Name())
+	assert.Equal(t, aggregate.Transport(), restored.Transport())
+	assert.Equal(t, aggregate.Speed(), restored.Speed())
+	assert.Equal(t, aggregate.Location(), restored.Location())
+	assert.Equal(t, aggregate.MovementBudget(), restored.MovementBudget())
+	assert.Equal(t, aggregate.Version(), restored.Version())
+	require.Len(t, restored.Places(), len(aggregate.Places()))
+	for i, place := range aggregate.Places() {
+		assert.Equal(t, place.ID(), restored.Places()[i].ID())
+		assert.Equal(t, place.Name(), restored.Places()[i].Name())
+		assert.Equal(t, place.TotalVolume(), restored.Places()[i].TotalVolume())
+		assert.Equal(t, place.OrderID(), restored.Places()[i].OrderID())
+	}
+}
+
+func TestMapper_DtoToDomain_InvalidLocation(t *testing.T) {
+	_, err := DtoToDomain(CourierDTO{ID: uuid.New(), Name: "Пеший", Transport: "pedestrian", Speed: 1})
+	assert.Error(t, err)
+}
+
+func TestMapper_DtoToDomain_UnknownTransport(t *testing.T) {
+	_, err := DtoToDomain(CourierDTO{ID: uuid.New(), Name: "Грузовик", Transport: "truck", Speed: 1,
+		Location: LocationDTO{X: 1, Y: 1}})
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
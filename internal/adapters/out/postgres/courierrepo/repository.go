@@ -0,0 +1,142 @@
+package courierrepo
+
+import (
+	"context"
+	"delivery/internal/adapters/out/postgres/tracking"
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ ports.CourierRepository = &Repository{}
+
+type Repository struct {
+	tracker tracking.Tracker
+}
+
+func NewRepository(tracker tracking.Tracker) (*Repository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &Repository{
+		tracker: tracker,
+	}, nil
+}
+
+func (r *Repository) Add(ctx context.Context, aggregate *courier.Courier) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+
+	r.tracker.Track(aggregate)
+
+	dto := DomainToDTO(aggregate)
+	dto.Version = aggregate.Version() + 1
+
+	return tracking.Save(ctx, r.tracker, aggregate, func(tx *gorm.DB) error {
+		return tx.WithContext(ctx).Create(&dto).Error
+	})
+}
+
+func (r *Repository) Update(ctx context.Context, aggregate *courier.Courier) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+
+	r.tracker.Track(aggregate)
+
+	dto := DomainToDTO(aggregate)
+	dto.Version = aggregate.Version() + 1
+
+	return tracking.Save(ctx, r.tracker, aggregate, func(tx *gorm.DB) error {
+		result := tx.WithContext(ctx).
+			Model(&dto).
+			Where("version = ?", aggregate.Version()).
+			Select("*").
+			Omit(clause.Associations).
+			Updates(&dto)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.NewVersionIsInvalidError("courier",
+				fmt.Errorf("courier %s was modified concurrently, expected version %d", aggregate.ID(), aggregate.Version()))
+		}
+
+		// Места, которых больше нет у курьера, удаляем, иначе они остались бы
+		// в БД без владельца и попадали бы в метрики вместимости
+		removed := tx.WithContext(ctx).Where("courier_id = ?", aggregate.ID())
+		if len(dto.StoragePlaces) > 0 {
+			ids := make([]uuid.UUID, 0, len(dto.StoragePlaces))
+			for _, place := range dto.StoragePlaces {
+				ids = append(ids, place.ID)
+			}
+			removed = removed.Where("id NOT IN ?", ids)
+		}
+		if err := removed.Delete(&StoragePlaceDTO{}).Error; err != nil {
+			return err
+		}
+
+		if len(dto.StoragePlaces) == 0 {
+			return nil
+		}
+		return tx.WithContext(ctx).
+			Clauses(clause.OnConflict{UpdateAll: true}).
+			Create(&dto.StoragePlaces).Error
+	})
+}
+
+func (r *Repository) Get(ctx context.Context, ID uuid.UUID) (*courier.Courier, error) {
+	dto := CourierDTO{}
+
+	err := tracking.TxOrDb(r.tracker).WithContext(ctx).
+		Preload("StoragePlaces", orderStoragePlaces).
+		First(&dto, "id = ?", ID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NewObjectNotFoundError("courierID", ID)
+		}
+		return nil, err
+	}
+
+	return DtoToDomain(dto)
+}
+
+func (r *Repository) GetAllFree(ctx context.Context) ([]*courier.Courier, error) {
+	var dtos []CourierDTO
+
+	err := tracking.TxOrDb(r.tracker).WithContext(ctx).
+		Preload("StoragePlaces", orderStoragePlaces).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM storage_places sp
+			WHERE sp.courier_id = couriers.id AND sp.order_id IS NOT NULL
+		)`).
+		Find(&dtos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates := make([]*courier.Courier, 0, len(dtos))
+	for _, dto := range dtos {
+		aggregate, err := DtoToDomain(dto)
+		if err != nil {
+			return nil, err
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+
+	return aggregates, nil
+}
+
+// Порядок мест хранения влияет на выбор места в Courier.TakeOrder,
+// поэтому восстанавливаем его таким, каким его сохранил агрегат.
+func orderStoragePlaces(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
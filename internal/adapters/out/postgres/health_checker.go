@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/health"
+
+	"gorm.io/gorm"
+)
+
+var _ health.Checker = &HealthChecker{}
+
+// HealthChecker проверяет доступность БД и сообщает состояние пула соединений
+type HealthChecker struct {
+	db *gorm.DB
+}
+
+func NewHealthChecker(db *gorm.DB) (*HealthChecker, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &HealthChecker{
+		db: db,
+	}, nil
+}
+
+func (c *HealthChecker) Check(ctx context.Context) (map[string]any, error) {
+	sqlDb, err := c.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := sqlDb.Stats()
+	details := map[string]any{
+		"openConnections":    stats.OpenConnections,
+		"inUse":              stats.InUse,
+		"idle":               stats.Idle,
+		"maxOpenConnections": stats.MaxOpenConnections,
+	}
+
+	if err := sqlDb.PingContext(ctx); err != nil {
+		return details, err
+	}
+	return details, nil
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"delivery/internal/adapters/out/postgres/outboxrepo"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/clock"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthChecker(t *testing.T) {
+	ctx, db := setupTest(t)
+
+	checker, err := NewHealthChecker(db)
+	require.NoError(t, err)
+
+	details, err := checker.Check(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, details, "openConnections")
+}
+
+func TestOutboxRepository_GetBacklog(t *testing.T) {
+	ctx, db := setupTest(t)
+
+	repository, err := outboxrepo.NewRepository(db)
+	require.NoError(t, err)
+
+	backlog, err := repository.GetBacklog(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), backlog.Count)
+	assert.Nil(t, backlog.OldestOccurredAtUtc)
+
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 1, 1)
+	require.NoError(t, err)
+	aggregate, err := order.NewOrder(uuid.New(), location, 1)
+	require.NoError(t, err)
+	uow, err := NewUnitOfWork(db, clock.System())
+	require.NoError(t, err)
+	require.NoError(t, uow.OrderRepository().Add(ctx, aggregate))
+
+	backlog, err = repository.GetBacklog(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), backlog.Count)
+	assert.NotNil(t, backlog.OldestOccurredAtUtc)
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS orders;
+DROP TABLE IF EXISTS storage_places;
+DROP TABLE IF EXISTS couriers;
@@ -0,0 +1,35 @@
+CREATE TABLE couriers
+(
+    id         uuid PRIMARY KEY,
+    name       varchar(255) NOT NULL,
+    speed      integer      NOT NULL,
+    location_x integer      NOT NULL,
+    location_y integer      NOT NULL,
+    version    integer      NOT NULL DEFAULT 0
+);
+
+CREATE TABLE storage_places
+(
+    id           uuid PRIMARY KEY,
+    name         varchar(255) NOT NULL,
+    total_volume integer      NOT NULL,
+    order_id     uuid         NULL,
+    courier_id   uuid         NOT NULL REFERENCES couriers (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_storage_places_courier_id ON storage_places (courier_id);
+
+CREATE TABLE orders
+(
+    id         uuid PRIMARY KEY,
+    courier_id uuid        NULL,
+    location_x integer     NOT NULL,
+    location_y integer     NOT NULL,
+    volume     integer     NOT NULL,
+    status     varchar(16) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    version    integer     NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_orders_courier_id ON orders (courier_id);
+CREATE INDEX idx_orders_status ON orders (status, created_at);
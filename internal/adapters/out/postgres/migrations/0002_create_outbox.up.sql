@@ -0,0 +1,10 @@
+CREATE TABLE outbox
+(
+    id               uuid PRIMARY KEY,
+    name             varchar(255) NOT NULL,
+    payload          jsonb        NOT NULL,
+    occurred_at_utc  timestamptz  NOT NULL,
+    processed_at_utc timestamptz  NULL
+);
+
+CREATE INDEX idx_outbox_not_processed ON outbox (occurred_at_utc) WHERE processed_at_utc IS NULL;
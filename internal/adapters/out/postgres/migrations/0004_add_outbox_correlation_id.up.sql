@@ -0,0 +1 @@
+ALTER TABLE outbox ADD COLUMN correlation_id varchar(64) NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+CREATE TABLE traffic_zones
+(
+    name        varchar(255) PRIMARY KEY,
+    from_x      integer      NOT NULL,
+    from_y      integer      NOT NULL,
+    to_x        integer      NOT NULL,
+    to_y        integer      NOT NULL,
+    multipliers jsonb        NOT NULL
+);
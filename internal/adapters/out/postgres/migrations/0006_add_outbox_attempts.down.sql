@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_outbox_not_processed;
+CREATE INDEX idx_outbox_not_processed ON outbox (occurred_at_utc) WHERE processed_at_utc IS NULL;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS quarantined_at_utc;
+ALTER TABLE outbox DROP COLUMN IF EXISTS last_error;
+ALTER TABLE outbox DROP COLUMN IF EXISTS attempts;
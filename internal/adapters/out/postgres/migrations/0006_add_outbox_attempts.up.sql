@@ -0,0 +1,6 @@
+ALTER TABLE outbox ADD COLUMN attempts integer NOT NULL DEFAULT 0;
+ALTER TABLE outbox ADD COLUMN last_error text NOT NULL DEFAULT '';
+ALTER TABLE outbox ADD COLUMN quarantined_at_utc timestamptz NULL;
+
+DROP INDEX IF EXISTS idx_outbox_not_processed;
+CREATE INDEX idx_outbox_not_processed ON outbox (occurred_at_utc) WHERE processed_at_utc IS NULL AND quarantined_at_utc IS NULL;
@@ -0,0 +1,11 @@
+ALTER TABLE couriers ADD COLUMN transport varchar(16) NOT NULL DEFAULT 'pedestrian';
+
+-- Раньше транспорт определялся скоростью: 1 — пеший, 2 — вело, 3 и больше — авто
+UPDATE couriers
+SET transport = CASE
+    WHEN speed <= 1 THEN 'pedestrian'
+    WHEN speed = 2 THEN 'bicycle'
+    ELSE 'car'
+END;
+
+ALTER TABLE couriers ALTER COLUMN transport DROP DEFAULT;
@@ -0,0 +1 @@
+ALTER TABLE couriers DROP COLUMN IF EXISTS movement_budget;
@@ -0,0 +1 @@
+ALTER TABLE couriers ADD COLUMN movement_budget double precision NOT NULL DEFAULT 0;
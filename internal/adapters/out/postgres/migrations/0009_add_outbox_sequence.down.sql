@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_outbox_not_processed;
+CREATE INDEX idx_outbox_not_processed ON outbox (occurred_at_utc) WHERE processed_at_utc IS NULL AND quarantined_at_utc IS NULL;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS sequence;
@@ -0,0 +1,16 @@
+CREATE SEQUENCE outbox_sequence_seq;
+ALTER TABLE outbox ADD COLUMN sequence bigint NULL;
+
+UPDATE outbox
+SET sequence = ordered.sequence
+FROM (SELECT id, row_number() OVER (ORDER BY occurred_at_utc, id) AS sequence FROM outbox) AS ordered
+WHERE outbox.id = ordered.id;
+
+SELECT setval('outbox_sequence_seq', COALESCE(MAX(sequence), 0) + 1, false) FROM outbox;
+
+ALTER TABLE outbox ALTER COLUMN sequence SET DEFAULT nextval('outbox_sequence_seq');
+ALTER TABLE outbox ALTER COLUMN sequence SET NOT NULL;
+ALTER SEQUENCE outbox_sequence_seq OWNED BY outbox.sequence;
+
+DROP INDEX IF EXISTS idx_outbox_not_processed;
+CREATE INDEX idx_outbox_not_processed ON outbox (sequence) WHERE processed_at_utc IS NULL AND quarantined_at_utc IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE storage_places DROP COLUMN IF EXISTS position;
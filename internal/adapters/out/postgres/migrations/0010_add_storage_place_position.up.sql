@@ -0,0 +1,7 @@
+ALTER TABLE storage_places ADD COLUMN position integer NOT NULL DEFAULT 0;
+
+UPDATE storage_places
+SET position = ordered.position
+FROM (SELECT id, row_number() OVER (PARTITION BY courier_id ORDER BY total_volume, id) - 1 AS position
+      FROM storage_places) AS ordered
+WHERE storage_places.id = ordered.id;
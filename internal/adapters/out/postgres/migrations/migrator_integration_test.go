@@ -0,0 +1,69 @@
+package migrations_test
+
+import (
+	"context"
+	"database/sql"
+	"delivery/internal/adapters/out/postgres/migrations"
+	"delivery/internal/pkg/testcnts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestMigrator(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+	container, dsn, err := testcnts.StartPostgresContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+
+	t.Run("container starts fully migrated", func(t *testing.T) {
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		for _, status := range statuses {
+			assert.NotNil(t, status.AppliedAt, "migration %d", status.Version)
+		}
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("down rolls back the last migration and up reapplies it", func(t *testing.T) {
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		last := statuses[len(statuses)-1]
+
+		version, err := migrator.Down(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, last.Version, version)
+
+		statuses, err = migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, statuses[len(statuses)-1].AppliedAt)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []int{last.Version}, applied)
+	})
+
+	t.Run("down all migrations", func(t *testing.T) {
+		for {
+			_, err := migrator.Down(ctx)
+			if err != nil {
+				assert.ErrorIs(t, err, migrations.ErrNoMigrationsToRollback)
+				break
+			}
+		}
+	})
+}
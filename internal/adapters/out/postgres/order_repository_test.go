@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/errs"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderRepository(t *testing.T) {
+	ctx, db := setupTest(t)
+
+	uow, err := NewUnitOfWork(db, clock.System())
+	require.NoError(t, err)
+	repository := uow.OrderRepository()
+
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 2, 4)
+	require.NoError(t, err)
+
+	first, err := order.NewOrder(uuid.New(), location, 5)
+	require.NoError(t, err)
+	second, err := order.NewOrder(uuid.New(), location, 7)
+	require.NoError(t, err)
+
+	require.NoError(t, repository.Add(ctx, first))
+	require.NoError(t, repository.Add(ctx, second))
+
+	t.Run("get", func(t *testing.T) {
+		restored, err := repository.Get(ctx, first.ID())
+		require.NoError(t, err)
+		assert.Equal(t, first.ID(), restored.ID())
+		assert.Equal(t, first.Location(), restored.Location())
+		assert.Equal(t, first.Volume(), restored.Volume())
+		assert.Equal(t, first.Status(), restored.Status())
+	})
+
+	t.Run("get unknown", func(t *testing.T) {
+		_, err := repository.Get(ctx, uuid.New())
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+	})
+
+	t.Run("add existing order", func(t *testing.T) {
+		duplicate, err := order.NewOrder(first.ID(), location, 5)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, repository.Add(ctx, duplicate), errs.ErrObjectAlreadyExists)
+		assert.Equal(t, 0, duplicate.Version())
+	})
+
+	t.Run("get oldest in created status", func(t *testing.T) {
+		created, err := repository.GetOldestInCreatedStatus(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, created, 2)
+		assert.Equal(t, first.ID(), created[0].ID())
+		assert.Equal(t, second.ID(), created[1].ID())
+
+		limited, err := repository.GetOldestInCreatedStatus(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, limited, 1)
+		assert.Equal(t, first.ID(), limited[0].ID())
+	})
+
+	t.Run("update and get all in assigned status", func(t *testing.T) {
+		courierID := uuid.New()
+		require.NoError(t, first.Assign(courierID))
+		require.NoError(t, repository.Update(ctx, first))
+
+		assigned, err := repository.GetAllInAssignedStatus(ctx)
+		require.NoError(t, err)
+		require.Len(t, assigned, 1)
+		assert.Equal(t, first.ID(), assigned[0].ID())
+		assert.Equal(t, courierID, *assigned[0].CourierID())
+
+		created, err := repository.GetOldestInCreatedStatus(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, created, 1)
+		assert.Equal(t, second.ID(), created[0].ID())
+	})
+}
+
+func TestOrderRepository_OptimisticConcurrency(t *testing.T) {
+	ctx, db := setupTest(t)
+
+	uow, err := NewUnitOfWork(db, clock.System())
+	require.NoError(t, err)
+	repository := uow.OrderRepository()
+
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 2, 4)
+	require.NoError(t, err)
+
+	aggregate, err := order.NewOrder(uuid.New(), location, 5)
+	require.NoError(t, err)
+	require.NoError(t, repository.Add(ctx, aggregate))
+	assert.Equal(t, 1, aggregate.Version())
+
+	first, err := repository.Get(ctx, aggregate.ID())
+	require.NoError(t, err)
+	second, err := repository.Get(ctx, aggregate.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, first.Assign(uuid.New()))
+	require.NoError(t, repository.Update(ctx, first))
+	assert.Equal(t, 2, first.Version())
+
+	require.NoError(t, second.Assign(uuid.New()))
+	err = repository.Update(ctx, second)
+	assert.ErrorIs(t, err, errs.ErrVersionIsInvalid)
+
+	restored, err := repository.Get(ctx, aggregate.ID())
+	require.NoError(t, err)
+	assert.Equal(t, *first.CourierID(), *restored.CourierID())
+	assert.Equal(t, 2, restored.Version())
+}
@@ -0,0 +1,26 @@
+package orderrepo
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderDTO struct {
+	ID        uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	CourierID *uuid.UUID  `gorm:"type:uuid;index"`
+	Location  LocationDTO `gorm:"embedded;embeddedPrefix:location_"`
+	Volume    int
+	Status    string    `gorm:"type:varchar(16);index"`
+	Version   int       `gorm:"not null;default:0"`
+	CreatedAt time.Time `gorm:"autoCreateTime;<-:create"`
+}
+
+type LocationDTO struct {
+	X int
+	Y int
+}
+
+func (OrderDTO) TableName() string {
+	return "orders"
+}
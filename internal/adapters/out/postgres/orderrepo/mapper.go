@@ -0,0 +1,34 @@
+package orderrepo
+
+import (
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+)
+
+func DomainToDTO(aggregate *order.Order) OrderDTO {
+	return OrderDTO{
+		ID:        aggregate.ID(),
+		CourierID: aggregate.CourierID(),
+		Location: LocationDTO{
+			X: aggregate.Location().X(),
+			Y: aggregate.Location().Y(),
+		},
+		Volume:  aggregate.Volume(),
+		Status:  aggregate.Status(),
+		Version: aggregate.Version(),
+	}
+}
+
+func DtoToDomain(dto OrderDTO) (*order.Order, error) {
+	location, err := kernel.RestoreLocation(dto.Location.X, dto.Location.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := order.ParseStatus(dto.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return order.RestoreOrder(dto.ID, dto.CourierID, location, dto.Volume, status, dto.Version), nil
+}
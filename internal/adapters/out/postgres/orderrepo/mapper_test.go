@@ -0,0 +1,65 @@
+package orderrepo
+
+import (
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapper_RoundTrip(t *testing.T) {
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 3, 7)
+	require.NoError(t, err)
+
+	t.Run("created order", func(t *testing.T) {
+		aggregate, err := order.NewOrder(uuid.New(), location, 5)
+		require.NoError(t, err)
+
+		restored, err := DtoToDomain(DomainToDTO(aggregate))
+		require.NoError(t, err)
+
+		assert.Equal(t, aggregate.ID(), restored.ID())
+		assert.Equal(t, aggregate.Location(), restored.Location())
+		assert.Equal(t, aggregate.Volume(), restored.Volume())
+		assert.Equal(t, aggregate.Status(), restored.Status())
+		assert.Nil(t, restored.CourierID())
+	})
+
+	t.Run("assigned order", func(t *testing.T) {
+		aggregate, err := order.NewOrder(uuid.New(), location, 5)
+		require.NoError(t, err)
+		courierID := uuid.New()
+		require.NoError(t, aggregate.Assign(courierID))
+
+		restored, err := DtoToDomain(DomainToDTO(aggregate))
+		require.NoError(t, err)
+
+		assert.Equal(t, order.Status(order.Assigned).String(), restored.Status())
+		assert.Equal(t, courierID, *restored.CourierID())
+	})
+
+	t.Run("version", func(t *testing.T) {
+		aggregate := order.RestoreOrder(uuid.New(), nil, location, 5, order.Created, 3)
+
+		restored, err := DtoToDomain(DomainToDTO(aggregate))
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, restored.Version())
+	})
+}
+
+func TestMapper_DtoToDomain(t *testing.T) {
+	t.Run("invalid location", func(t *testing.T) {
+		_, err := DtoToDomain(OrderDTO{ID: uuid.New(), Location: LocationDTO{X: 0, Y: 0}, Volume: 1, Status: "Created"})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		_, err := DtoToDomain(OrderDTO{ID: uuid.New(), Location: LocationDTO{X: 1, Y: 1}, Volume: 1, Status: "Lost"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "status")
+	})
+}
@@ -0,0 +1,149 @@
+package orderrepo
+
+import (
+	"context"
+	"delivery/internal/adapters/out/postgres/tracking"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ ports.OrderRepository = &Repository{}
+
+type Repository struct {
+	tracker tracking.Tracker
+}
+
+func NewRepository(tracker tracking.Tracker) (*Repository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &Repository{
+		tracker: tracker,
+	}, nil
+}
+
+func (r *Repository) Add(ctx context.Context, aggregate *order.Order) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+
+	r.tracker.Track(aggregate)
+
+	dto := DomainToDTO(aggregate)
+	dto.Version = aggregate.Version() + 1
+
+	return tracking.Save(ctx, r.tracker, aggregate, func(tx *gorm.DB) error {
+		// Заказ с тем же ID мог создать параллельный обработчик той же корзины
+		result := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&dto)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.NewObjectAlreadyExistsError("orderID", aggregate.ID())
+		}
+		return nil
+	})
+}
+
+func (r *Repository) Update(ctx context.Context, aggregate *order.Order) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+
+	r.tracker.Track(aggregate)
+
+	dto := DomainToDTO(aggregate)
+	dto.Version = aggregate.Version() + 1
+
+	return tracking.Save(ctx, r.tracker, aggregate, func(tx *gorm.DB) error {
+		result := tx.WithContext(ctx).
+			Model(&dto).
+			Where("version = ?", aggregate.Version()).
+			Select("*").
+			Updates(&dto)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.NewVersionIsInvalidError("order",
+				fmt.Errorf("order %s was modified concurrently, expected version %d", aggregate.ID(), aggregate.Version()))
+		}
+		return nil
+	})
+}
+
+func (r *Repository) Get(ctx context.Context, ID uuid.UUID) (*order.Order, error) {
+	dto := OrderDTO{}
+
+	err := tracking.TxOrDb(r.tracker).WithContext(ctx).First(&dto, "id = ?", ID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NewObjectNotFoundError("orderID", ID)
+		}
+		return nil, err
+	}
+
+	return DtoToDomain(dto)
+}
+
+// GetOldestInCreatedStatus возвращает не больше limit ожидающих назначения
+// заказов, начиная с самого старого
+func (r *Repository) GetOldestInCreatedStatus(ctx context.Context, limit int) ([]*order.Order, error) {
+	if limit <= 0 {
+		return nil, errs.NewValueIsOutOfRangeError("limit", limit, 1, math.MaxInt)
+	}
+
+	var dtos []OrderDTO
+
+	err := tracking.TxOrDb(r.tracker).WithContext(ctx).
+		Where("status = ?", order.Status(order.Created).String()).
+		Order("created_at").
+		Limit(limit).
+		Find(&dtos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates := make([]*order.Order, 0, len(dtos))
+	for _, dto := range dtos {
+		aggregate, err := DtoToDomain(dto)
+		if err != nil {
+			return nil, err
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+
+	return aggregates, nil
+}
+
+func (r *Repository) GetAllInAssignedStatus(ctx context.Context) ([]*order.Order, error) {
+	var dtos []OrderDTO
+
+	err := tracking.TxOrDb(r.tracker).WithContext(ctx).
+		Where("status = ?", order.Status(order.Assigned).String()).
+		Order("created_at").
+		Find(&dtos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates := make([]*order.Order, 0, len(dtos))
+	for _, dto := range dtos {
+		aggregate, err := DtoToDomain(dto)
+		if err != nil {
+			return nil, err
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+
+	return aggregates, nil
+}
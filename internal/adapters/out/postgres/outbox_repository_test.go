@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"delivery/internal/adapters/out/postgres/outboxrepo"
+	"delivery/internal/pkg/outbox"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func createOutboxMessages(t *testing.T, ctx context.Context, db *gorm.DB, count int) []*outbox.Message {
+	t.Helper()
+	occurredAt := time.Now().UTC().Add(-time.Minute)
+
+	messages := make([]*outbox.Message, 0, count)
+	for i := range count {
+		message := &outbox.Message{
+			ID:            uuid.New(),
+			Name:          "testEvent",
+			Payload:       []byte("{}"),
+			OccurredAtUtc: occurredAt.Add(time.Duration(i) * time.Second),
+		}
+		require.NoError(t, db.WithContext(ctx).Create(message).Error)
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func TestOutboxRepository(t *testing.T) {
+	ctx, db := setupTest(t)
+
+	repository, err := outboxrepo.NewRepository(db)
+	require.NoError(t, err)
+
+	t.Run("skips messages locked by another relay", func(t *testing.T) {
+		messages := createOutboxMessages(t, ctx, db, 2)
+
+		tx := db.Begin()
+		defer tx.Rollback()
+		lockingRepository, err := outboxrepo.NewRepository(tx)
+		require.NoError(t, err)
+		locked, err := lockingRepository.GetNotPublishedMessages(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, locked, 1)
+		assert.Equal(t, messages[0].ID, locked[0].ID)
+
+		err = repository.InTx(ctx, func(ctx context.Context, repository outbox.Repository) error {
+			pending, err := repository.GetNotPublishedMessages(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, pending, 1)
+			assert.Equal(t, messages[1].ID, pending[0].ID)
+			return repository.MarkAsProcessed(ctx, pending[0].ID, time.Now().UTC())
+		})
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback().Error)
+		require.NoError(t, repository.MarkAsProcessed(ctx, messages[0].ID, time.Now().UTC()))
+	})
+
+	t.Run("quarantined messages are not published", func(t *testing.T) {
+		messages := createOutboxMessages(t, ctx, db, 2)
+
+		quarantinedAt := time.Now().UTC()
+		require.NoError(t, repository.MarkAsFailed(ctx, messages[0].ID, 3, "broker is down", &quarantinedAt))
+
+		err := repository.InTx(ctx, func(ctx context.Context, repository outbox.Repository) error {
+			pending, err := repository.GetNotPublishedMessages(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, pending, 1)
+			assert.Equal(t, messages[1].ID, pending[0].ID)
+			return nil
+		})
+		require.NoError(t, err)
+
+		backlog, err := repository.GetBacklog(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), backlog.Count)
+		assert.Equal(t, int64(1), backlog.Quarantined)
+
+		var stored outbox.Message
+		require.NoError(t, db.WithContext(ctx).First(&stored, "id = ?", messages[0].ID).Error)
+		assert.Equal(t, 3, stored.Attempts)
+		assert.Equal(t, "broker is down", stored.LastError)
+		assert.NotNil(t, stored.QuarantinedAtUtc)
+	})
+
+	t.Run("messages of one transaction keep insertion order", func(t *testing.T) {
+		occurredAt := time.Now().UTC()
+		messages := make([]outbox.Message, 0, 3)
+		for range 3 {
+			messages = append(messages, outbox.Message{
+				ID:            uuid.New(),
+				Name:          "testEvent",
+				Payload:       []byte("{}"),
+				OccurredAtUtc: occurredAt,
+			})
+		}
+		require.NoError(t, db.WithContext(ctx).Create(&messages).Error)
+
+		pending, err := repository.GetNotPublishedMessages(ctx, 10)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(pending), 3)
+		// Сообщения прошлых подтестов старше и идут первыми
+		pending = pending[len(pending)-3:]
+		for i, message := range messages {
+			assert.Equal(t, message.ID, pending[i].ID)
+			require.NoError(t, repository.MarkAsProcessed(ctx, message.ID, time.Now().UTC()))
+		}
+	})
+
+	t.Run("mark unknown message as failed", func(t *testing.T) {
+		err := repository.MarkAsFailed(ctx, uuid.New(), 1, "boom", nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,112 @@
+package outboxrepo
+
+import (
+	"context"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/outbox"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ outbox.Repository = &Repository{}
+var _ outbox.BacklogReader = &Repository{}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) (*Repository, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &Repository{
+		db: db,
+	}, nil
+}
+
+func (r *Repository) InTx(ctx context.Context, fn func(ctx context.Context, repository outbox.Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(ctx, &Repository{db: tx})
+	})
+}
+
+// GetNotPublishedMessages блокирует выбранные строки до конца транзакции.
+// Вне InTx блокировка снимается сразу после запроса.
+func (r *Repository) GetNotPublishedMessages(ctx context.Context, limit int) ([]*outbox.Message, error) {
+	var messages []*outbox.Message
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("processed_at_utc IS NULL AND quarantined_at_utc IS NULL").
+		Order("sequence").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *Repository) MarkAsProcessed(ctx context.Context, ID uuid.UUID, processedAtUtc time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&outbox.Message{}).
+		Where("id = ?", ID).
+		Update("processed_at_utc", processedAtUtc)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NewObjectNotFoundError("messageID", ID)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkAsFailed(ctx context.Context, ID uuid.UUID, attempts int, lastError string, quarantinedAtUtc *time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&outbox.Message{}).
+		Where("id = ?", ID).
+		Updates(map[string]any{
+			"attempts":           attempts,
+			"last_error":         lastError,
+			"quarantined_at_utc": quarantinedAtUtc,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NewObjectNotFoundError("messageID", ID)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetBacklog(ctx context.Context) (outbox.Backlog, error) {
+	var row struct {
+		Count       int64
+		Oldest      *time.Time
+		Quarantined int64
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&outbox.Message{}).
+		Select("COUNT(*) FILTER (WHERE quarantined_at_utc IS NULL) AS count, " +
+			"MIN(occurred_at_utc) FILTER (WHERE quarantined_at_utc IS NULL) AS oldest, " +
+			"COUNT(*) FILTER (WHERE quarantined_at_utc IS NOT NULL) AS quarantined").
+		Where("processed_at_utc IS NULL").
+		Scan(&row).Error
+	if err != nil {
+		return outbox.Backlog{}, err
+	}
+
+	return outbox.Backlog{
+		Count:               row.Count,
+		OldestOccurredAtUtc: row.Oldest,
+		Quarantined:         row.Quarantined,
+	}, nil
+}
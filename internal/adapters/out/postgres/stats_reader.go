@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"delivery/internal/adapters/out/postgres/courierrepo"
+	"delivery/internal/adapters/out/postgres/orderrepo"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/metrics"
+
+	"gorm.io/gorm"
+)
+
+var _ metrics.StateReader = &StatsReader{}
+
+// StatsReader считает агрегаты для метрик одним запросом на метрику, не
+// загружая агрегаты в память
+type StatsReader struct {
+	db *gorm.DB
+}
+
+func NewStatsReader(db *gorm.DB) (*StatsReader, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &StatsReader{
+		db: db,
+	}, nil
+}
+
+func (r *StatsReader) CountOrdersByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&orderrepo.OrderDTO{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *StatsReader) CountStoragePlaces(ctx context.Context) (int64, int64, error) {
+	var row struct {
+		Occupied int64
+		Free     int64
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&courierrepo.StoragePlaceDTO{}).
+		Select("COUNT(order_id) AS occupied, COUNT(*) - COUNT(order_id) AS free").
+		Scan(&row).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	return row.Occupied, row.Free, nil
+}
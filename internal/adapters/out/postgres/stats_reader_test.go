@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/clock"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsReader(t *testing.T) {
+	ctx, db := setupTest(t)
+
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 1, 1)
+	require.NoError(t, err)
+	aggregate, err := order.NewOrder(uuid.New(), location, 1)
+	require.NoError(t, err)
+	courierAggregate, err := courier.NewCourier("Иван", kernel.Bicycle, 2, location)
+	require.NoError(t, err)
+
+	uow, err := NewUnitOfWork(db, clock.System())
+	require.NoError(t, err)
+	require.NoError(t, uow.OrderRepository().Add(ctx, aggregate))
+	require.NoError(t, uow.CourierRepository().Add(ctx, courierAggregate))
+
+	reader, err := NewStatsReader(db)
+	require.NoError(t, err)
+
+	counts, err := reader.CountOrdersByStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{order.Status(order.Created).String(): 1}, counts)
+
+	occupied, free, err := reader.CountStoragePlaces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), occupied)
+	assert.Equal(t, int64(1), free)
+}
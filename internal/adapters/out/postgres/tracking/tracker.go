@@ -0,0 +1,81 @@
+package tracking
+
+import (
+	"context"
+	"delivery/internal/pkg/ddd"
+
+	"gorm.io/gorm"
+)
+
+// Tracker — то, что репозиториям нужно от Unit of Work: текущая транзакция и
+// учет измененных агрегатов, чьи доменные события попадут в outbox
+type Tracker interface {
+	Tx() *gorm.DB
+	Db() *gorm.DB
+	InTx() bool
+	Track(agg ddd.AggregateRoot)
+	// OnRollback регистрирует действие, которое отменит изменения в памяти,
+	// если транзакция не будет зафиксирована
+	OnRollback(fn func())
+	Begin(ctx context.Context) error
+	Commit(ctx context.Context) error
+	Rollback() error
+}
+
+// InTx выполняет fn в транзакции Unit of Work. Если вызов пришел вне Unit of
+// Work, открывает собственную транзакцию, чтобы доменные события все равно
+// попали в outbox вместе с изменениями.
+func InTx(ctx context.Context, tracker Tracker, fn func(tx *gorm.DB) error) error {
+	isInTransaction := tracker.InTx()
+	if !isInTransaction {
+		if err := tracker.Begin(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := fn(tracker.Tx()); err != nil {
+		if !isInTransaction {
+			_ = tracker.Rollback()
+		}
+		return err
+	}
+
+	if !isInTransaction {
+		return tracker.Commit(ctx)
+	}
+	return nil
+}
+
+// TxOrDb читает внутри открытой транзакции, чтобы видеть ее изменения
+func TxOrDb(tracker Tracker) *gorm.DB {
+	if tx := tracker.Tx(); tx != nil {
+		return tx
+	}
+	return tracker.Db()
+}
+
+// versioned — агрегат с версией для оптимистичной блокировки
+type versioned interface {
+	Version() int
+	IncrementVersion()
+	RestoreVersion(version int)
+}
+
+// Save выполняет запись агрегата и повышает его версию. Если транзакция
+// потом откатится, версия вернется к прочитанной: иначе повтор команды
+// ожидал бы в БД версию, которую она так и не увидела.
+func Save(ctx context.Context, tracker Tracker, aggregate versioned, fn func(tx *gorm.DB) error) error {
+	version := aggregate.Version()
+	if err := InTx(ctx, tracker, func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		tracker.OnRollback(func() { aggregate.RestoreVersion(version) })
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	aggregate.IncrementVersion()
+	return nil
+}
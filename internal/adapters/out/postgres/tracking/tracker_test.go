@@ -0,0 +1,96 @@
+package tracking
+
+import (
+	"context"
+	"delivery/internal/pkg/ddd"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fakeTracker struct {
+	inTx          bool
+	commits       int
+	rollbackHooks []func()
+}
+
+func (t *fakeTracker) Tx() *gorm.DB {
+	return nil
+}
+
+func (t *fakeTracker) Db() *gorm.DB {
+	return nil
+}
+
+func (t *fakeTracker) InTx() bool {
+	return t.inTx
+}
+
+func (t *fakeTracker) Track(_ ddd.AggregateRoot) {}
+
+func (t *fakeTracker) OnRollback(fn func()) {
+	t.rollbackHooks = append(t.rollbackHooks, fn)
+}
+
+func (t *fakeTracker) Begin(_ context.Context) error {
+	t.inTx = true
+	return nil
+}
+
+func (t *fakeTracker) Commit(_ context.Context) error {
+	t.inTx = false
+	t.commits++
+	t.rollbackHooks = nil
+	return nil
+}
+
+func (t *fakeTracker) Rollback() error {
+	for _, hook := range t.rollbackHooks {
+		hook()
+	}
+	t.inTx = false
+	t.rollbackHooks = nil
+	return nil
+}
+
+func TestSave(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("restores version on rollback", func(t *testing.T) {
+		tracker := &fakeTracker{}
+		aggregate := ddd.RestoreBaseAggregate(uuid.New(), 3)
+		require.NoError(t, tracker.Begin(ctx))
+
+		require.NoError(t, Save(ctx, tracker, aggregate, func(*gorm.DB) error { return nil }))
+		assert.Equal(t, 4, aggregate.Version())
+
+		require.NoError(t, tracker.Rollback())
+		assert.Equal(t, 3, aggregate.Version())
+	})
+
+	t.Run("commits own transaction", func(t *testing.T) {
+		tracker := &fakeTracker{}
+		aggregate := ddd.RestoreBaseAggregate(uuid.New(), 3)
+
+		require.NoError(t, Save(ctx, tracker, aggregate, func(*gorm.DB) error { return nil }))
+
+		assert.Equal(t, 4, aggregate.Version())
+		assert.Equal(t, 1, tracker.commits)
+		assert.False(t, tracker.InTx())
+	})
+
+	t.Run("keeps version when write fails", func(t *testing.T) {
+		tracker := &fakeTracker{}
+		aggregate := ddd.RestoreBaseAggregate(uuid.New(), 3)
+
+		err := Save(ctx, tracker, aggregate, func(*gorm.DB) error { return errors.New("conflict") })
+
+		assert.Error(t, err)
+		assert.Equal(t, 3, aggregate.Version())
+		assert.False(t, tracker.InTx())
+	})
+}
@@ -0,0 +1,17 @@
+package trafficzonerepo
+
+type TrafficZoneDTO struct {
+	Name        string             `gorm:"type:varchar(255);primaryKey"`
+	From        LocationDTO        `gorm:"embedded;embeddedPrefix:from_"`
+	To          LocationDTO        `gorm:"embedded;embeddedPrefix:to_"`
+	Multipliers map[string]float64 `gorm:"type:jsonb;serializer:json"`
+}
+
+type LocationDTO struct {
+	X int
+	Y int
+}
+
+func (TrafficZoneDTO) TableName() string {
+	return "traffic_zones"
+}
@@ -0,0 +1,41 @@
+package trafficzonerepo
+
+import (
+	"delivery/internal/core/domain/models/kernel"
+)
+
+func DomainToDTO(zone kernel.TrafficZone) TrafficZoneDTO {
+	multipliers := make(map[string]float64, len(zone.Multipliers()))
+	for transport, multiplier := range zone.Multipliers() {
+		multipliers[transport.String()] = multiplier
+	}
+
+	return TrafficZoneDTO{
+		Name:        zone.Name(),
+		From:        LocationDTO{X: zone.From().X(), Y: zone.From().Y()},
+		To:          LocationDTO{X: zone.To().X(), Y: zone.To().Y()},
+		Multipliers: multipliers,
+	}
+}
+
+func DtoToDomain(dto TrafficZoneDTO) (kernel.TrafficZone, error) {
+	from, err := kernel.RestoreLocation(dto.From.X, dto.From.Y)
+	if err != nil {
+		return kernel.TrafficZone{}, err
+	}
+	to, err := kernel.RestoreLocation(dto.To.X, dto.To.Y)
+	if err != nil {
+		return kernel.TrafficZone{}, err
+	}
+
+	multipliers := make(map[kernel.Transport]float64, len(dto.Multipliers))
+	for name, multiplier := range dto.Multipliers {
+		transport, err := kernel.ParseTransport(name)
+		if err != nil {
+			return kernel.TrafficZone{}, err
+		}
+		multipliers[transport] = multiplier
+	}
+
+	return kernel.NewTrafficZone(dto.Name, from, to, multipliers)
+}
@@ -0,0 +1,65 @@
+package trafficzonerepo
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ ports.TrafficZoneRepository = &Repository{}
+
+// Repository хранит районы пробок вне агрегатов и без Unit of Work: каждое
+// изменение района самостоятельно
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) (*Repository, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &Repository{
+		db: db,
+	}, nil
+}
+
+func (r *Repository) GetAll(ctx context.Context) ([]kernel.TrafficZone, error) {
+	var dtos []TrafficZoneDTO
+	if err := r.db.WithContext(ctx).Order("name").Find(&dtos).Error; err != nil {
+		return nil, err
+	}
+
+	zones := make([]kernel.TrafficZone, 0, len(dtos))
+	for _, dto := range dtos {
+		zone, err := DtoToDomain(dto)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+	return zones, nil
+}
+
+// Save создает район или заменяет район с тем же именем
+func (r *Repository) Save(ctx context.Context, zone kernel.TrafficZone) error {
+	dto := DomainToDTO(zone)
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&dto).Error
+}
+
+func (r *Repository) Remove(ctx context.Context, name string) error {
+	result := r.db.WithContext(ctx).Delete(&TrafficZoneDTO{}, "name = ?", name)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NewObjectNotFoundError("name", name)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"delivery/internal/adapters/out/postgres/courierrepo"
+	"delivery/internal/adapters/out/postgres/orderrepo"
+	"delivery/internal/adapters/out/postgres/tracking"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/correlation"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/outbox"
+	"delivery/internal/pkg/tracing"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTransactionNotStarted     = errors.New("transaction is not started")
+	ErrTransactionAlreadyStarted = errors.New("transaction is already started")
+)
+
+var _ ports.UnitOfWork = &UnitOfWork{}
+var _ tracking.Tracker = &UnitOfWork{}
+
+type UnitOfWork struct {
+	db                *gorm.DB
+	tx                *gorm.DB
+	trackedAggregates []ddd.AggregateRoot
+	rollbackHooks     []func()
+	orderRepository   ports.OrderRepository
+	courierRepository ports.CourierRepository
+	clock             clock.Clock
+}
+
+func NewUnitOfWork(db *gorm.DB, clk clock.Clock) (*UnitOfWork, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+	if clk == nil {
+		return nil, errs.NewValueIsRequiredError("clk")
+	}
+
+	uow := &UnitOfWork{
+		db:    db,
+		clock: clk,
+	}
+
+	orderRepository, err := orderrepo.NewRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+	uow.orderRepository = orderRepository
+
+	courierRepository, err := courierrepo.NewRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+	uow.courierRepository = courierRepository
+
+	return uow, nil
+}
+
+func (u *UnitOfWork) Tx() *gorm.DB {
+	return u.tx
+}
+
+func (u *UnitOfWork) Db() *gorm.DB {
+	return u.db
+}
+
+func (u *UnitOfWork) InTx() bool {
+	return u.tx != nil
+}
+
+func (u *UnitOfWork) Track(agg ddd.AggregateRoot) {
+	for _, tracked := range u.trackedAggregates {
+		if tracked == agg {
+			return
+		}
+	}
+	u.trackedAggregates = append(u.trackedAggregates, agg)
+}
+
+func (u *UnitOfWork) OnRollback(fn func()) {
+	u.rollbackHooks = append(u.rollbackHooks, fn)
+}
+
+func (u *UnitOfWork) Begin(ctx context.Context) error {
+	if u.tx != nil {
+		return ErrTransactionAlreadyStarted
+	}
+
+	tx := u.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	u.tx = tx
+	return nil
+}
+
+// Commit сохраняет доменные события отслеживаемых агрегатов в outbox в той же
+// транзакции, что и сами агрегаты. События очищаются только после успешного
+// коммита, иначе при повторной попытке они будут записаны снова.
+func (u *UnitOfWork) Commit(ctx context.Context) error {
+	if u.tx == nil {
+		return ErrTransactionNotStarted
+	}
+
+	if err := u.saveDomainEvents(ctx); err != nil {
+		_ = u.Rollback()
+		return err
+	}
+
+	if err := u.tx.Commit().Error; err != nil {
+		u.undo()
+		u.reset()
+		return err
+	}
+
+	for _, aggregate := range u.trackedAggregates {
+		aggregate.ClearDomainEvents()
+	}
+	u.reset()
+
+	return nil
+}
+
+func (u *UnitOfWork) Rollback() error {
+	if u.tx == nil {
+		return ErrTransactionNotStarted
+	}
+
+	err := u.tx.Rollback().Error
+	u.undo()
+	u.reset()
+
+	return err
+}
+
+func (u *UnitOfWork) OrderRepository() ports.OrderRepository {
+	return u.orderRepository
+}
+
+func (u *UnitOfWork) CourierRepository() ports.CourierRepository {
+	return u.courierRepository
+}
+
+// saveDomainEvents сохраняет с событиями трассу и correlation ID текущей
+// операции: для событий, поднятых фоновой задачей, это трасса ее тика, а не
+// запроса, создавшего агрегат
+func (u *UnitOfWork) saveDomainEvents(ctx context.Context) error {
+	var messages []outbox.Message
+	traceContext := tracing.Inject(ctx)
+	correlationID := correlation.CorrelationID(ctx)
+	for _, aggregate := range u.trackedAggregates {
+		for _, event := range aggregate.GetDomainEvents() {
+			message, err := outbox.EncodeDomainEvent(event, u.clock)
+			if err != nil {
+				return err
+			}
+			message.TraceContext = traceContext
+			message.CorrelationID = correlationID
+			messages = append(messages, message)
+		}
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	if err := u.tx.WithContext(ctx).Create(&messages).Error; err != nil {
+		return fmt.Errorf("failed to save outbox messages: %w", err)
+	}
+	return nil
+}
+
+// undo отменяет изменения агрегатов в памяти, сделанные незафиксированной
+// транзакцией, в обратном порядке
+func (u *UnitOfWork) undo() {
+	for i := len(u.rollbackHooks) - 1; i >= 0; i-- {
+		u.rollbackHooks[i]()
+	}
+}
+
+func (u *UnitOfWork) reset() {
+	u.tx = nil
+	u.trackedAggregates = nil
+	u.rollbackHooks = nil
+}
+
+type UnitOfWorkFactory struct {
+	db    *gorm.DB
+	clock clock.Clock
+}
+
+var _ ports.UnitOfWorkFactory = &UnitOfWorkFactory{}
+
+func NewUnitOfWorkFactory(db *gorm.DB, clk clock.Clock) (*UnitOfWorkFactory, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+	if clk == nil {
+		return nil, errs.NewValueIsRequiredError("clk")
+	}
+
+	return &UnitOfWorkFactory{
+		db:    db,
+		clock: clk,
+	}, nil
+}
+
+func (f *UnitOfWorkFactory) New() (ports.UnitOfWork, error) {
+	return NewUnitOfWork(f.db, f.clock)
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/outbox"
+	"delivery/internal/pkg/testcnts"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type testEvent struct {
+	ID uuid.UUID
+}
+
+func (e testEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e testEvent) GetName() string {
+	return "testEvent"
+}
+
+func setupTest(t *testing.T) (context.Context, *gorm.DB) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+	container, dsn, err := testcnts.StartPostgresContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	return ctx, db
+}
+
+func TestUnitOfWork(t *testing.T) {
+	ctx, db := setupTest(t)
+
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), 5, 5)
+	require.NoError(t, err)
+
+	t.Run("commit saves aggregate and outbox messages", func(t *testing.T) {
+		uow, err := NewUnitOfWork(db, clock.System())
+		require.NoError(t, err)
+
+		aggregate, err := order.NewOrder(uuid.New(), location, 5)
+		require.NoError(t, err)
+		event := testEvent{ID: uuid.New()}
+		aggregate.RaiseDomainEvent(event)
+
+		require.NoError(t, uow.Begin(ctx))
+		require.NoError(t, uow.OrderRepository().Add(ctx, aggregate))
+		require.NoError(t, uow.Commit(ctx))
+
+		assert.Empty(t, aggregate.GetDomainEvents())
+		assert.False(t, uow.InTx())
+
+		var message outbox.Message
+		require.NoError(t, db.First(&message, "id = ?", event.ID).Error)
+		assert.Equal(t, event.GetName(), message.Name)
+		assert.Nil(t, message.ProcessedAtUtc)
+
+		var created outbox.Message
+		require.NoError(t, db.First(&created, "name = ?", "OrderCreatedDomainEvent").Error)
+		assert.Nil(t, created.ProcessedAtUtc)
+	})
+
+	t.Run("rollback discards aggregate and keeps domain events", func(t *testing.T) {
+		uow, err := NewUnitOfWork(db, clock.System())
+		require.NoError(t, err)
+
+		aggregate, err := order.NewOrder(uuid.New(), location, 5)
+		require.NoError(t, err)
+		aggregate.RaiseDomainEvent(testEvent{ID: uuid.New()})
+
+		require.NoError(t, uow.Begin(ctx))
+		require.NoError(t, uow.OrderRepository().Add(ctx, aggregate))
+		require.NoError(t, uow.Rollback())
+
+		// OrderCreatedDomainEvent и тестовое событие
+		assert.Len(t, aggregate.GetDomainEvents(), 2)
+		assert.Equal(t, 0, aggregate.Version())
+
+		_, err = uow.OrderRepository().Get(ctx, aggregate.ID())
+		assert.Error(t, err)
+
+		// В outbox только события из предыдущего, закоммиченного подтеста
+		var count int64
+		require.NoError(t, db.Model(&outbox.Message{}).Count(&count).Error)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("repository call outside transaction commits on its own", func(t *testing.T) {
+		uow, err := NewUnitOfWork(db, clock.System())
+		require.NoError(t, err)
+
+		aggregate, err := order.NewOrder(uuid.New(), location, 5)
+		require.NoError(t, err)
+		aggregate.RaiseDomainEvent(testEvent{ID: uuid.New()})
+
+		require.NoError(t, uow.OrderRepository().Add(ctx, aggregate))
+
+		assert.False(t, uow.InTx())
+		assert.Empty(t, aggregate.GetDomainEvents())
+	})
+}
+
+func TestUnitOfWork_WithoutTransaction(t *testing.T) {
+	uow, err := NewUnitOfWork(&gorm.DB{}, clock.System())
+	require.NoError(t, err)
+
+	assert.True(t, errors.Is(uow.Commit(context.Background()), ErrTransactionNotStarted))
+	assert.True(t, errors.Is(uow.Rollback(), ErrTransactionNotStarted))
+}
@@ -0,0 +1,52 @@
+package costmap
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"sync/atomic"
+)
+
+var _ kernel.CostMapSource = &Holder{}
+
+// Holder держит в памяти действующую карту стоимости, собранную из районов
+// пробок в БД. Расчеты маршрутов читают карту без обращения к БД, а Refresh
+// подхватывает изменения, сделанные в том числе другими экземплярами сервиса.
+type Holder struct {
+	repository ports.TrafficZoneRepository
+	costMap    atomic.Pointer[kernel.CostMap]
+}
+
+func NewHolder(repository ports.TrafficZoneRepository) (*Holder, error) {
+	if repository == nil {
+		return nil, errs.NewValueIsRequiredError("repository")
+	}
+
+	holder := &Holder{
+		repository: repository,
+	}
+	holder.costMap.Store(&kernel.CostMap{})
+	return holder, nil
+}
+
+// CostMap возвращает карту, загруженную последним успешным Refresh. До первой
+// загрузки пробок нет.
+func (h *Holder) CostMap() kernel.CostMap {
+	return *h.costMap.Load()
+}
+
+// Refresh перечитывает районы из БД. При ошибке остается прежняя карта.
+func (h *Holder) Refresh(ctx context.Context) error {
+	zones, err := h.repository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	costMap, err := kernel.NewCostMap(zones)
+	if err != nil {
+		return err
+	}
+	h.costMap.Store(&costMap)
+	return nil
+}
@@ -0,0 +1,63 @@
+package costmap
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTrafficZoneRepository struct {
+	zones []kernel.TrafficZone
+	err   error
+}
+
+func (r *fakeTrafficZoneRepository) GetAll(_ context.Context) ([]kernel.TrafficZone, error) {
+	return r.zones, r.err
+}
+
+func (r *fakeTrafficZoneRepository) Save(_ context.Context, zone kernel.TrafficZone) error {
+	r.zones = append(r.zones, zone)
+	return nil
+}
+
+func (r *fakeTrafficZoneRepository) Remove(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestNewHolder(t *testing.T) {
+	_, err := NewHolder(nil)
+	assert.Error(t, err)
+}
+
+func TestHolder_Refresh(t *testing.T) {
+	ctx := context.Background()
+	repository := &fakeTrafficZoneRepository{}
+	holder, err := NewHolder(repository)
+	require.NoError(t, err)
+
+	// До первой загрузки пробок нет
+	assert.True(t, holder.CostMap().IsEmpty())
+
+	from, err := kernel.NewLocation(kernel.DefaultGrid(), 2, 2)
+	require.NoError(t, err)
+	to, err := kernel.NewLocation(kernel.DefaultGrid(), 4, 4)
+	require.NoError(t, err)
+	zone, err := kernel.NewTrafficZone("center", from, to, map[kernel.Transport]float64{kernel.Car: 2})
+	require.NoError(t, err)
+	require.NoError(t, repository.Save(ctx, zone))
+
+	require.NoError(t, holder.Refresh(ctx))
+	assert.Equal(t, []kernel.TrafficZone{zone}, holder.CostMap().Zones())
+	assert.Equal(t, 2.0, holder.CostMap().StepCost(from, kernel.Car))
+
+	t.Run("keeps previous map on error", func(t *testing.T) {
+		repository.err = errors.New("db is down")
+
+		assert.Error(t, holder.Refresh(ctx))
+		assert.Equal(t, []kernel.TrafficZone{zone}, holder.CostMap().Zones())
+	})
+}
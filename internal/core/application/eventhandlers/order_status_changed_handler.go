@@ -0,0 +1,34 @@
+package eventhandlers
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/errs"
+)
+
+var _ ddd.EventHandler = &OrderStatusChangedDomainEventHandler{}
+
+// OrderStatusChangedDomainEventHandler передает события о смене статуса заказа
+// во внешний мир. Вызывается outbox relay, поэтому ошибка публикации оставляет
+// сообщение в outbox и оно будет отправлено повторно.
+type OrderStatusChangedDomainEventHandler struct {
+	orderProducer ports.OrderProducer
+}
+
+func NewOrderStatusChangedDomainEventHandler(orderProducer ports.OrderProducer) (*OrderStatusChangedDomainEventHandler, error) {
+	if orderProducer == nil {
+		return nil, errs.NewValueIsRequiredError("orderProducer")
+	}
+
+	return &OrderStatusChangedDomainEventHandler{
+		orderProducer: orderProducer,
+	}, nil
+}
+
+func (h *OrderStatusChangedDomainEventHandler) Handle(ctx context.Context, domainEvent ddd.DomainEvent) error {
+	if domainEvent == nil {
+		return errs.NewValueIsRequiredError("domainEvent")
+	}
+	return h.orderProducer.Publish(ctx, domainEvent)
+}
@@ -0,0 +1,57 @@
+package eventhandlers
+
+import (
+	"context"
+	"delivery/internal/pkg/ddd"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	ID uuid.UUID
+}
+
+func (e *testEvent) GetID() uuid.UUID { return e.ID }
+func (e *testEvent) GetName() string  { return "testEvent" }
+
+type fakeOrderProducer struct {
+	published []ddd.DomainEvent
+	err       error
+}
+
+func (p *fakeOrderProducer) Publish(_ context.Context, domainEvent ddd.DomainEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, domainEvent)
+	return nil
+}
+
+func TestNewOrderStatusChangedDomainEventHandler(t *testing.T) {
+	_, err := NewOrderStatusChangedDomainEventHandler(nil)
+	assert.Error(t, err)
+}
+
+func TestOrderStatusChangedDomainEventHandler_PublishesEvent(t *testing.T) {
+	producer := &fakeOrderProducer{}
+	handler, err := NewOrderStatusChangedDomainEventHandler(producer)
+	require.NoError(t, err)
+
+	event := &testEvent{ID: uuid.New()}
+	require.NoError(t, handler.Handle(context.Background(), event))
+
+	assert.Equal(t, []ddd.DomainEvent{event}, producer.published)
+}
+
+func TestOrderStatusChangedDomainEventHandler_ReturnsProducerError(t *testing.T) {
+	producer := &fakeOrderProducer{err: errors.New("broker is down")}
+	handler, err := NewOrderStatusChangedDomainEventHandler(producer)
+	require.NoError(t, err)
+
+	err = handler.Handle(context.Background(), &testEvent{ID: uuid.New()})
+	assert.ErrorIs(t, err, producer.err)
+}
@@ -0,0 +1,13 @@
+package commands
+
+type AssignOrdersCommand struct {
+	isSet bool
+}
+
+func NewAssignOrdersCommand() (AssignOrdersCommand, error) {
+	return AssignOrdersCommand{isSet: true}, nil
+}
+
+func (c AssignOrdersCommand) IsEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/services"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"fmt"
+)
+
+var ErrNoSuitableCourier = errors.New("no suitable courier for order")
+
+// assignOrdersBatchSize ограничивает число ожидающих заказов, которые
+// перебираются за один запуск
+const assignOrdersBatchSize = 100
+
+type AssignOrdersCommandHandler interface {
+	Handle(ctx context.Context, command AssignOrdersCommand) error
+}
+
+var _ AssignOrdersCommandHandler = &assignOrdersCommandHandler{}
+
+type assignOrdersCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+	cityMap           kernel.CityMap
+	orderDispatcher   services.OrderDispatcher
+}
+
+func NewAssignOrdersCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory,
+	cityMap kernel.CityMap,
+	orderDispatcher services.OrderDispatcher,
+) (AssignOrdersCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+	if cityMap.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("cityMap")
+	}
+	if orderDispatcher == nil {
+		return nil, errs.NewValueIsRequiredError("orderDispatcher")
+	}
+
+	return &assignOrdersCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+		cityMap:           cityMap,
+		orderDispatcher:   orderDispatcher,
+	}, nil
+}
+
+// Handle назначает самый старый созданный заказ, для которого нашелся
+// подходящий свободный курьер. Заказ, который ни один курьер не может взять
+// или до которого нет маршрута, пропускается и не задерживает следующие.
+// Заказы и курьеры за пределами уменьшенной сетки не участвуют в назначении:
+// курьер не смог бы до них дойти и снял бы заказ на следующем шаге.
+// Если заказов нет, ничего не делает. Если не удалось назначить ни один,
+// возвращает ErrNoSuitableCourier и оставляет заказы в статусе Created.
+func (h *assignOrdersCommandHandler) Handle(ctx context.Context, command AssignOrdersCommand) error {
+	if command.IsEmpty() {
+		return errs.NewValueIsRequiredError("command")
+	}
+
+	uow, err := h.unitOfWorkFactory.New()
+	if err != nil {
+		return err
+	}
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+	defer func() { _ = uow.Rollback() }()
+
+	orders, err := uow.OrderRepository().GetOldestInCreatedStatus(ctx, assignOrdersBatchSize)
+	if err != nil {
+		return err
+	}
+	if len(orders) == 0 {
+		return nil
+	}
+
+	couriers, err := uow.CourierRepository().GetAllFree(ctx)
+	if err != nil {
+		return err
+	}
+	grid := h.cityMap.Grid()
+	inside := couriers[:0]
+	for _, free := range couriers {
+		if grid.Contains(free.Location()) {
+			inside = append(inside, free)
+		}
+	}
+	couriers = inside
+	if len(couriers) == 0 {
+		return fmt.Errorf("%w: order %s", ErrNoSuitableCourier, orders[0].ID())
+	}
+
+	for _, order := range orders {
+		if !grid.Contains(order.Location()) {
+			continue
+		}
+
+		courier, err := h.orderDispatcher.Dispatch(order, couriers)
+		if err != nil {
+			if errors.Is(err, services.ErrCourierNotFound) {
+				continue
+			}
+			return err
+		}
+
+		if err := uow.OrderRepository().Update(ctx, order); err != nil {
+			return err
+		}
+		if err := uow.CourierRepository().Update(ctx, courier); err != nil {
+			return err
+		}
+
+		return uow.Commit(ctx)
+	}
+
+	return fmt.Errorf("%w: %d orders", ErrNoSuitableCourier, len(orders))
+}
@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/core/domain/services"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignOrdersCommandHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	command, err := NewAssignOrdersCommand()
+	require.NoError(t, err)
+
+	setup := func(t *testing.T) (*fakeUnitOfWork, AssignOrdersCommandHandler) {
+		uow := newFakeUnitOfWork()
+		dispatcher, err := services.NewOrderDispatcher(kernel.GridDistance{})
+		require.NoError(t, err)
+		handler, err := NewAssignOrdersCommandHandler(uow, mustCreateCityMap(t), dispatcher)
+		require.NoError(t, err)
+		return uow, handler
+	}
+
+	t.Run("assigns order to free courier", func(t *testing.T) {
+		uow, handler := setup(t)
+
+		created, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 5)
+		require.NoError(t, err)
+		require.NoError(t, uow.OrderRepository().Add(ctx, created))
+
+		free, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		require.NoError(t, uow.CourierRepository().Add(ctx, free))
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, order.Status(order.Assigned).String(), created.Status())
+		assert.Equal(t, free.ID(), *created.CourierID())
+		assert.Equal(t, []uuid.UUID{created.ID()}, uow.orderRepository.updated)
+		assert.Equal(t, []uuid.UUID{free.ID()}, uow.courierRepository.updated)
+		assert.Equal(t, 1, uow.commits)
+	})
+
+	t.Run("does nothing without created orders", func(t *testing.T) {
+		uow, handler := setup(t)
+
+		require.NoError(t, handler.Handle(ctx, command))
+		assert.Equal(t, 0, uow.commits)
+	})
+
+	t.Run("leaves order when no courier fits", func(t *testing.T) {
+		uow, handler := setup(t)
+
+		created, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 50)
+		require.NoError(t, err)
+		require.NoError(t, uow.OrderRepository().Add(ctx, created))
+
+		small, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		require.NoError(t, uow.CourierRepository().Add(ctx, small))
+
+		err = handler.Handle(ctx, command)
+		assert.ErrorIs(t, err, ErrNoSuitableCourier)
+		assert.Equal(t, order.Status(order.Created).String(), created.Status())
+		assert.Empty(t, uow.orderRepository.updated)
+		assert.Equal(t, 0, uow.commits)
+	})
+
+	t.Run("leaves order when there are no free couriers", func(t *testing.T) {
+		uow, handler := setup(t)
+
+		created, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 5)
+		require.NoError(t, err)
+		require.NoError(t, uow.OrderRepository().Add(ctx, created))
+
+		err = handler.Handle(ctx, command)
+		assert.ErrorIs(t, err, ErrNoSuitableCourier)
+	})
+
+	t.Run("skips order no courier can take", func(t *testing.T) {
+		uow, handler := setup(t)
+
+		large, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 50)
+		require.NoError(t, err)
+		require.NoError(t, uow.OrderRepository().Add(ctx, large))
+		next, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 3, 3), 5)
+		require.NoError(t, err)
+		require.NoError(t, uow.OrderRepository().Add(ctx, next))
+
+		free, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		require.NoError(t, uow.CourierRepository().Add(ctx, free))
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, order.Status(order.Created).String(), large.Status())
+		assert.Equal(t, order.Status(order.Assigned).String(), next.Status())
+		assert.Equal(t, []uuid.UUID{next.ID()}, uow.orderRepository.updated)
+		assert.Equal(t, 1, uow.commits)
+	})
+
+	t.Run("skips unreachable order", func(t *testing.T) {
+		uow := newFakeUnitOfWork()
+		// Клетка (1, 1) отрезана от остального города
+		cityMap := mustCreateCityMap(t, mustCreateLocation(t, 1, 2), mustCreateLocation(t, 2, 1))
+		distance, err := kernel.NewPathDistance(cityMap, kernel.CostMap{})
+		require.NoError(t, err)
+		dispatcher, err := services.NewOrderDispatcher(distance)
+		require.NoError(t, err)
+		handler, err := NewAssignOrdersCommandHandler(uow, cityMap, dispatcher)
+		require.NoError(t, err)
+
+		walledOff, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 1, 1), 5)
+		require.NoError(t, err)
+		require.NoError(t, uow.OrderRepository().Add(ctx, walledOff))
+		next, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 3, 3), 5)
+		require.NoError(t, err)
+		require.NoError(t, uow.OrderRepository().Add(ctx, next))
+
+		free, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, mustCreateLocation(t, 5, 5))
+		require.NoError(t, err)
+		require.NoError(t, uow.CourierRepository().Add(ctx, free))
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, order.Status(order.Created).String(), walledOff.Status())
+		assert.Equal(t, free.ID(), *next.CourierID())
+	})
+
+	t.Run("ignores orders and couriers outside a shrunk grid", func(t *testing.T) {
+		uow := newFakeUnitOfWork()
+		grid, err := kernel.NewGridOfSize(5, 5)
+		require.NoError(t, err)
+		shrunk, err := kernel.NewCityMap(grid, nil)
+		require.NoError(t, err)
+		dispatcher, err := services.NewOrderDispatcher(kernel.GridDistance{})
+		require.NoError(t, err)
+		handler, err := NewAssignOrdersCommandHandler(uow, shrunk, dispatcher)
+		require.NoError(t, err)
+
+		outsideOrder, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 9, 9), 5)
+		require.NoError(t, err)
+		require.NoError(t, uow.OrderRepository().Add(ctx, outsideOrder))
+		insideOrder, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 5)
+		require.NoError(t, err)
+		require.NoError(t, uow.OrderRepository().Add(ctx, insideOrder))
+
+		outsideCourier, err := courier.NewCourier("Авто", kernel.Car, 4, mustCreateLocation(t, 6, 6))
+		require.NoError(t, err)
+		require.NoError(t, uow.CourierRepository().Add(ctx, outsideCourier))
+		insideCourier, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		require.NoError(t, uow.CourierRepository().Add(ctx, insideCourier))
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, order.Status(order.Created).String(), outsideOrder.Status())
+		assert.Equal(t, insideCourier.ID(), *insideOrder.CourierID())
+	})
+
+	t.Run("empty command", func(t *testing.T) {
+		_, handler := setup(t)
+		assert.Error(t, handler.Handle(ctx, AssignOrdersCommand{}))
+	})
+}
+
+func mustCreateLocation(t *testing.T, x, y int) kernel.Location {
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), x, y)
+	require.NoError(t, err)
+	return location
+}
+
+func mustCreateCityMap(t *testing.T, blocked ...kernel.Location) kernel.CityMap {
+	cityMap, err := kernel.NewCityMap(kernel.DefaultGrid(), blocked)
+	require.NoError(t, err)
+	return cityMap
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/pkg/errs"
+	"math"
+)
+
+type CreateCourierCommand struct {
+	name      string
+	transport kernel.Transport
+	speed     int
+
+	isSet bool
+}
+
+func NewCreateCourierCommand(name string, transport kernel.Transport, speed int) (CreateCourierCommand, error) {
+	if name == "" {
+		return CreateCourierCommand{}, errs.NewValueIsRequiredError("name")
+	}
+	if !transport.IsValid() {
+		return CreateCourierCommand{}, errs.NewValueIsInvalidError("transport")
+	}
+	if speed <= 0 {
+		return CreateCourierCommand{}, errs.NewValueIsOutOfRangeError("speed", speed, 1, math.MaxInt)
+	}
+
+	return CreateCourierCommand{
+		name:      name,
+		transport: transport,
+		speed:     speed,
+
+		isSet: true,
+	}, nil
+}
+
+func (c CreateCourierCommand) Name() string {
+	return c.name
+}
+
+func (c CreateCourierCommand) Transport() kernel.Transport {
+	return c.transport
+}
+
+func (c CreateCourierCommand) Speed() int {
+	return c.speed
+}
+
+func (c CreateCourierCommand) IsEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/random"
+)
+
+type CreateCourierCommandHandler interface {
+	Handle(ctx context.Context, command CreateCourierCommand) error
+}
+
+var _ CreateCourierCommandHandler = &createCourierCommandHandler{}
+
+type createCourierCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+	cityMap           kernel.CityMap
+	randomSource      random.Source
+}
+
+func NewCreateCourierCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory,
+	cityMap kernel.CityMap,
+	randomSource random.Source,
+) (CreateCourierCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+	if cityMap.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("cityMap")
+	}
+	if randomSource == nil {
+		return nil, errs.NewValueIsRequiredError("randomSource")
+	}
+
+	return &createCourierCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+		cityMap:           cityMap,
+		randomSource:      randomSource,
+	}, nil
+}
+
+// Handle создает курьера со стандартной сумкой в случайной проходимой точке города.
+func (h *createCourierCommandHandler) Handle(ctx context.Context, command CreateCourierCommand) error {
+	if command.IsEmpty() {
+		return errs.NewValueIsRequiredError("command")
+	}
+
+	location, err := h.cityMap.NewRandomLocation(h.randomSource)
+	if err != nil {
+		return err
+	}
+
+	aggregate, err := courier.NewCourier(command.Name(), command.Transport(), command.Speed(), location)
+	if err != nil {
+		return err
+	}
+
+	uow, err := h.unitOfWorkFactory.New()
+	if err != nil {
+		return err
+	}
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+	defer func() { _ = uow.Rollback() }()
+
+	if err := uow.CourierRepository().Add(ctx, aggregate); err != nil {
+		return err
+	}
+
+	return uow.Commit(ctx)
+}
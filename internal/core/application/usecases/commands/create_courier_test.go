@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/pkg/random"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCreateCourierCommand(t *testing.T) {
+	_, err := NewCreateCourierCommand("", kernel.Pedestrian, 1)
+	assert.ErrorContains(t, err, "name")
+
+	_, err = NewCreateCourierCommand("Пеший", kernel.Transport(42), 1)
+	assert.ErrorContains(t, err, "transport")
+
+	_, err = NewCreateCourierCommand("Пеший", kernel.Pedestrian, 0)
+	assert.ErrorContains(t, err, "speed")
+
+	command, err := NewCreateCourierCommand("Пеший", kernel.Pedestrian, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Пеший", command.Name())
+	assert.Equal(t, kernel.Pedestrian, command.Transport())
+	assert.Equal(t, 1, command.Speed())
+	assert.False(t, command.IsEmpty())
+}
+
+func TestCreateCourierCommandHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	uow := newFakeUnitOfWork()
+	handler, err := NewCreateCourierCommandHandler(uow, mustCreateCityMap(t), random.NewFake(4, 8))
+	require.NoError(t, err)
+
+	// Быстрый велосипедист: транспорт не зависит от скорости
+	command, err := NewCreateCourierCommand("Вело", kernel.Bicycle, 4)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, command))
+
+	require.Len(t, uow.courierRepository.couriers, 1)
+	for _, created := range uow.courierRepository.couriers {
+		assert.Equal(t, "Вело", created.Name())
+		assert.Equal(t, kernel.Bicycle, created.Transport())
+		assert.Equal(t, 4, created.Speed())
+		assert.Equal(t, 5, created.Location().X())
+		assert.Equal(t, 9, created.Location().Y())
+		assert.Len(t, created.Places(), 1)
+	}
+	assert.Equal(t, 1, uow.commits)
+
+	assert.Error(t, handler.Handle(ctx, CreateCourierCommand{}))
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"delivery/internal/pkg/errs"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+type CreateOrderCommand struct {
+	orderID uuid.UUID
+	street  string
+	volume  int
+
+	isSet bool
+}
+
+func NewCreateOrderCommand(orderID uuid.UUID, street string, volume int) (CreateOrderCommand, error) {
+	if orderID == uuid.Nil {
+		return CreateOrderCommand{}, errs.NewValueIsRequiredError("orderID")
+	}
+	if street == "" {
+		return CreateOrderCommand{}, errs.NewValueIsRequiredError("street")
+	}
+	if volume <= 0 {
+		return CreateOrderCommand{}, errs.NewValueIsOutOfRangeError("volume", volume, 1, math.MaxInt)
+	}
+
+	return CreateOrderCommand{
+		orderID: orderID,
+		street:  street,
+		volume:  volume,
+
+		isSet: true,
+	}, nil
+}
+
+func (c CreateOrderCommand) OrderID() uuid.UUID {
+	return c.orderID
+}
+
+func (c CreateOrderCommand) Street() string {
+	return c.street
+}
+
+func (c CreateOrderCommand) Volume() int {
+	return c.volume
+}
+
+func (c CreateOrderCommand) IsEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"fmt"
+)
+
+type CreateOrderCommandHandler interface {
+	Handle(ctx context.Context, command CreateOrderCommand) error
+}
+
+var ErrImpassableOrderLocation = errors.New("order location is impassable")
+
+var _ CreateOrderCommandHandler = &createOrderCommandHandler{}
+
+type createOrderCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+	geoClient         ports.GeoClient
+	cityMap           kernel.CityMap
+}
+
+func NewCreateOrderCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory,
+	geoClient ports.GeoClient,
+	cityMap kernel.CityMap,
+) (CreateOrderCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+	if geoClient == nil {
+		return nil, errs.NewValueIsRequiredError("geoClient")
+	}
+	if cityMap.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("cityMap")
+	}
+
+	return &createOrderCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+		geoClient:         geoClient,
+		cityMap:           cityMap,
+	}, nil
+}
+
+// Handle создает заказ с идентификатором корзины. Повторная обработка той же
+// корзины не создает второй заказ, поэтому команду можно безопасно повторять,
+// в том числе параллельно: проигравший гонку обработчик ничего не сохраняет.
+// Сервис Geo вызывается до открытия транзакции, чтобы не держать ее на время
+// сетевого запроса. Заказ в непроходимой клетке отклоняется: ни один курьер
+// не смог бы до него дойти.
+func (h *createOrderCommandHandler) Handle(ctx context.Context, command CreateOrderCommand) error {
+	if command.IsEmpty() {
+		return errs.NewValueIsRequiredError("command")
+	}
+
+	uow, err := h.unitOfWorkFactory.New()
+	if err != nil {
+		return err
+	}
+
+	_, err = uow.OrderRepository().Get(ctx, command.OrderID())
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, errs.ErrObjectNotFound) {
+		return err
+	}
+
+	location, err := h.geoClient.GetGeolocation(ctx, command.Street())
+	if err != nil {
+		return err
+	}
+
+	if !h.cityMap.IsPassable(location) {
+		return fmt.Errorf("%w: %w", errs.NewValueIsInvalidError("location"), ErrImpassableOrderLocation)
+	}
+
+	aggregate, err := order.NewOrder(command.OrderID(), location, command.Volume())
+	if err != nil {
+		return err
+	}
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+	defer func() { _ = uow.Rollback() }()
+
+	if err := uow.OrderRepository().Add(ctx, aggregate); err != nil {
+		if errors.Is(err, errs.ErrObjectAlreadyExists) {
+			return nil
+		}
+		return err
+	}
+
+	return uow.Commit(ctx)
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCreateOrderCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		orderID uuid.UUID
+		street  string
+		volume  int
+		errMsg  string
+	}{
+		{name: "nil order ID", orderID: uuid.Nil, street: "Тверская", volume: 1, errMsg: "orderID"},
+		{name: "empty street", orderID: uuid.New(), street: "", volume: 1, errMsg: "street"},
+		{name: "zero volume", orderID: uuid.New(), street: "Тверская", volume: 0, errMsg: "volume"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := NewCreateOrderCommand(tt.orderID, tt.street, tt.volume)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.True(t, command.IsEmpty())
+		})
+	}
+
+	t.Run("valid command", func(t *testing.T) {
+		orderID := uuid.New()
+		command, err := NewCreateOrderCommand(orderID, "Тверская", 5)
+		require.NoError(t, err)
+		assert.False(t, command.IsEmpty())
+		assert.Equal(t, orderID, command.OrderID())
+		assert.Equal(t, "Тверская", command.Street())
+		assert.Equal(t, 5, command.Volume())
+	})
+}
+
+func TestCreateOrderCommandHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates order at street location", func(t *testing.T) {
+		uow := newFakeUnitOfWork()
+		geoClient := newFakeGeoClient(3, 7)
+		handler, err := NewCreateOrderCommandHandler(uow, geoClient, mustCreateCityMap(t))
+		require.NoError(t, err)
+
+		command, err := NewCreateOrderCommand(uuid.New(), "Тверская", 5)
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		created, err := uow.OrderRepository().Get(ctx, command.OrderID())
+		require.NoError(t, err)
+		assert.Equal(t, 5, created.Volume())
+		assert.Equal(t, order.Status(order.Created).String(), created.Status())
+		assert.True(t, created.Location().Equals(geoClient.location))
+		assert.Equal(t, []string{"Тверская"}, geoClient.streets)
+		assert.Equal(t, 1, uow.commits)
+	})
+
+	t.Run("geo service error", func(t *testing.T) {
+		uow := newFakeUnitOfWork()
+		geoClient := &fakeGeoClient{err: errors.New("geo is down")}
+		handler, err := NewCreateOrderCommandHandler(uow, geoClient, mustCreateCityMap(t))
+		require.NoError(t, err)
+
+		command, err := NewCreateOrderCommand(uuid.New(), "Тверская", 5)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, handler.Handle(ctx, command), geoClient.err)
+		assert.Empty(t, uow.orderRepository.orders)
+		assert.Equal(t, 0, uow.commits)
+	})
+
+	t.Run("repeated basket does not create second order", func(t *testing.T) {
+		uow := newFakeUnitOfWork()
+		handler, err := NewCreateOrderCommandHandler(uow, newFakeGeoClient(1, 1), mustCreateCityMap(t))
+		require.NoError(t, err)
+
+		command, err := NewCreateOrderCommand(uuid.New(), "Тверская", 5)
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(ctx, command))
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Len(t, uow.orderRepository.orders, 1)
+		assert.Equal(t, 1, uow.commits)
+	})
+
+	t.Run("concurrent delivery of same basket", func(t *testing.T) {
+		uow := newFakeUnitOfWork()
+		command, err := NewCreateOrderCommand(uuid.New(), "Тверская", 5)
+		require.NoError(t, err)
+		// Пока ждем Geo, тот же заказ успевает сохранить другой обработчик
+		geoClient := &racingGeoClient{fakeGeoClient: newFakeGeoClient(1, 1), onCall: func() {
+			assert.False(t, uow.inTx, "geo must be called outside the transaction")
+			concurrent, err := order.NewOrder(command.OrderID(), newFakeGeoClient(1, 1).location, 5)
+			require.NoError(t, err)
+			require.NoError(t, uow.orderRepository.Add(ctx, concurrent))
+		}}
+		handler, err := NewCreateOrderCommandHandler(uow, geoClient, mustCreateCityMap(t))
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Len(t, uow.orderRepository.orders, 1)
+		assert.Equal(t, 0, uow.commits)
+		assert.False(t, uow.inTx)
+	})
+
+	t.Run("rejects order on impassable cell", func(t *testing.T) {
+		uow := newFakeUnitOfWork()
+		geoClient := newFakeGeoClient(3, 7)
+		handler, err := NewCreateOrderCommandHandler(uow, geoClient, mustCreateCityMap(t, geoClient.location))
+		require.NoError(t, err)
+
+		command, err := NewCreateOrderCommand(uuid.New(), "Набережная", 5)
+		require.NoError(t, err)
+
+		err = handler.Handle(ctx, command)
+		assert.ErrorIs(t, err, ErrImpassableOrderLocation)
+		assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+		assert.Empty(t, uow.orderRepository.orders)
+		assert.Equal(t, 0, uow.commits)
+	})
+
+	t.Run("empty command", func(t *testing.T) {
+		handler, err := NewCreateOrderCommandHandler(newFakeUnitOfWork(), newFakeGeoClient(1, 1), mustCreateCityMap(t))
+		require.NoError(t, err)
+
+		assert.Error(t, handler.Handle(ctx, CreateOrderCommand{}))
+	})
+}
+
+type racingGeoClient struct {
+	*fakeGeoClient
+	onCall func()
+}
+
+func (c *racingGeoClient) GetGeolocation(ctx context.Context, street string) (kernel.Location, error) {
+	c.onCall()
+	return c.fakeGeoClient.GetGeolocation(ctx, street)
+}
+
+func TestNewCreateOrderCommandHandler(t *testing.T) {
+	_, err := NewCreateOrderCommandHandler(nil, newFakeGeoClient(1, 1), mustCreateCityMap(t))
+	assert.Error(t, err)
+
+	_, err = NewCreateOrderCommandHandler(newFakeUnitOfWork(), nil, mustCreateCityMap(t))
+	assert.Error(t, err)
+
+	_, err = NewCreateOrderCommandHandler(newFakeUnitOfWork(), newFakeGeoClient(1, 1), kernel.CityMap{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type fakeOrderRepository struct {
+	orders  map[uuid.UUID]*order.Order
+	added   []uuid.UUID
+	updated []uuid.UUID
+}
+
+func (r *fakeOrderRepository) Add(_ context.Context, aggregate *order.Order) error {
+	if _, ok := r.orders[aggregate.ID()]; ok {
+		return errs.NewObjectAlreadyExistsError("orderID", aggregate.ID())
+	}
+	r.orders[aggregate.ID()] = aggregate
+	r.added = append(r.added, aggregate.ID())
+	return nil
+}
+
+func (r *fakeOrderRepository) Update(_ context.Context, aggregate *order.Order) error {
+	r.orders[aggregate.ID()] = aggregate
+	r.updated = append(r.updated, aggregate.ID())
+	return nil
+}
+
+func (r *fakeOrderRepository) Get(_ context.Context, ID uuid.UUID) (*order.Order, error) {
+	aggregate, ok := r.orders[ID]
+	if !ok {
+		return nil, errs.NewObjectNotFoundError("orderID", ID)
+	}
+	return aggregate, nil
+}
+
+func (r *fakeOrderRepository) GetOldestInCreatedStatus(_ context.Context, limit int) ([]*order.Order, error) {
+	var result []*order.Order
+	for _, ID := range r.added {
+		if aggregate := r.orders[ID]; aggregate.Status() == order.Status(order.Created).String() && len(result) < limit {
+			result = append(result, aggregate)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeOrderRepository) GetAllInAssignedStatus(_ context.Context) ([]*order.Order, error) {
+	var result []*order.Order
+	for _, aggregate := range r.orders {
+		if aggregate.Status() == order.Status(order.Assigned).String() {
+			result = append(result, aggregate)
+		}
+	}
+	return result, nil
+}
+
+type fakeCourierRepository struct {
+	couriers map[uuid.UUID]*courier.Courier
+	updated  []uuid.UUID
+}
+
+func (r *fakeCourierRepository) Add(_ context.Context, aggregate *courier.Courier) error {
+	r.couriers[aggregate.ID()] = aggregate
+	return nil
+}
+
+func (r *fakeCourierRepository) Update(_ context.Context, aggregate *courier.Courier) error {
+	r.couriers[aggregate.ID()] = aggregate
+	r.updated = append(r.updated, aggregate.ID())
+	return nil
+}
+
+func (r *fakeCourierRepository) Get(_ context.Context, ID uuid.UUID) (*courier.Courier, error) {
+	aggregate, ok := r.couriers[ID]
+	if !ok {
+		return nil, errs.NewObjectNotFoundError("courierID", ID)
+	}
+	return aggregate, nil
+}
+
+func (r *fakeCourierRepository) GetAllFree(_ context.Context) ([]*courier.Courier, error) {
+	var result []*courier.Courier
+	for _, aggregate := range r.couriers {
+		free := true
+		for _, place := range aggregate.Places() {
+			if place.OrderID() != nil {
+				free = false
+			}
+		}
+		if free {
+			result = append(result, aggregate)
+		}
+	}
+	return result, nil
+}
+
+type fakeUnitOfWork struct {
+	orderRepository   *fakeOrderRepository
+	courierRepository *fakeCourierRepository
+	inTx              bool
+	commits           int
+}
+
+func newFakeUnitOfWork() *fakeUnitOfWork {
+	return &fakeUnitOfWork{
+		orderRepository:   &fakeOrderRepository{orders: make(map[uuid.UUID]*order.Order)},
+		courierRepository: &fakeCourierRepository{couriers: make(map[uuid.UUID]*courier.Courier)},
+	}
+}
+
+func (u *fakeUnitOfWork) Begin(_ context.Context) error {
+	u.inTx = true
+	return nil
+}
+
+func (u *fakeUnitOfWork) Commit(_ context.Context) error {
+	if !u.inTx {
+		return errors.New("transaction is not started")
+	}
+	u.inTx = false
+	u.commits++
+	return nil
+}
+
+func (u *fakeUnitOfWork) Rollback() error {
+	u.inTx = false
+	return nil
+}
+
+func (u *fakeUnitOfWork) OrderRepository() ports.OrderRepository {
+	return u.orderRepository
+}
+
+func (u *fakeUnitOfWork) CourierRepository() ports.CourierRepository {
+	return u.courierRepository
+}
+
+func (u *fakeUnitOfWork) New() (ports.UnitOfWork, error) {
+	return u, nil
+}
+
+type fakeGeoClient struct {
+	location kernel.Location
+	err      error
+	streets  []string
+}
+
+func newFakeGeoClient(x int, y int) *fakeGeoClient {
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), x, y)
+	if err != nil {
+		panic(err)
+	}
+	return &fakeGeoClient{location: location}
+}
+
+func (c *fakeGeoClient) GetGeolocation(_ context.Context, street string) (kernel.Location, error) {
+	c.streets = append(c.streets, street)
+	if c.err != nil {
+		return kernel.Location{}, c.err
+	}
+	return c.location, nil
+}
+
+type fakeTrafficZoneRepository struct {
+	zones map[string]kernel.TrafficZone
+}
+
+func newFakeTrafficZoneRepository() *fakeTrafficZoneRepository {
+	return &fakeTrafficZoneRepository{zones: make(map[string]kernel.TrafficZone)}
+}
+
+func (r *fakeTrafficZoneRepository) GetAll(_ context.Context) ([]kernel.TrafficZone, error) {
+	var zones []kernel.TrafficZone
+	for _, zone := range r.zones {
+		zones = append(zones, zone)
+	}
+	return zones, nil
+}
+
+func (r *fakeTrafficZoneRepository) Save(_ context.Context, zone kernel.TrafficZone) error {
+	r.zones[zone.Name()] = zone
+	return nil
+}
+
+func (r *fakeTrafficZoneRepository) Remove(_ context.Context, name string) error {
+	if _, ok := r.zones[name]; !ok {
+		return errs.NewObjectNotFoundError("name", name)
+	}
+	delete(r.zones, name)
+	return nil
+}
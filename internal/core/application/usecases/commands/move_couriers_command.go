@@ -0,0 +1,13 @@
+package commands
+
+type MoveCouriersCommand struct {
+	isSet bool
+}
+
+func NewMoveCouriersCommand() (MoveCouriersCommand, error) {
+	return MoveCouriersCommand{isSet: true}, nil
+}
+
+func (c MoveCouriersCommand) IsEmpty() bool {
+	return !c.isSet
+}
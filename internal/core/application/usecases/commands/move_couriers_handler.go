@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+type MoveCouriersCommandHandler interface {
+	Handle(ctx context.Context, command MoveCouriersCommand) error
+}
+
+var _ MoveCouriersCommandHandler = &moveCouriersCommandHandler{}
+
+type moveCouriersCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+	cityMap           kernel.CityMap
+	costs             kernel.CostMapSource
+	logger            *slog.Logger
+}
+
+func NewMoveCouriersCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory,
+	cityMap kernel.CityMap,
+	costs kernel.CostMapSource,
+	logger *slog.Logger,
+) (MoveCouriersCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+	if cityMap.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("cityMap")
+	}
+	if costs == nil {
+		return nil, errs.NewValueIsRequiredError("costs")
+	}
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	return &moveCouriersCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+		cityMap:           cityMap,
+		costs:             costs,
+		logger:            logger,
+	}, nil
+}
+
+// Handle делает один шаг каждым курьером с назначенным заказом. Курьер,
+// добравшийся до точки доставки, завершает заказ. Все изменения сохраняются
+// в одной транзакции. Курьер, к заказу которого нет проходимого маршрута,
+// отказывается от него, а заказ возвращается в очередь на назначение:
+// остальные курьеры продолжают движение. Так же снимаются заказы, оказавшиеся
+// за пределами уменьшенной сетки, и все заказы курьера, который сам оказался
+// за ее пределами. Карта стоимости читается один раз за тик, чтобы все
+// курьеры видели одни и те же пробки.
+func (h *moveCouriersCommandHandler) Handle(ctx context.Context, command MoveCouriersCommand) error {
+	if command.IsEmpty() {
+		return errs.NewValueIsRequiredError("command")
+	}
+
+	uow, err := h.unitOfWorkFactory.New()
+	if err != nil {
+		return err
+	}
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+	defer func() { _ = uow.Rollback() }()
+
+	orders, err := uow.OrderRepository().GetAllInAssignedStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if len(orders) == 0 {
+		return nil
+	}
+
+	costs := h.costs.CostMap()
+
+	// Курьер с несколькими заказами делает за тик только один шаг
+	grid := h.cityMap.Grid()
+	moved := make(map[uuid.UUID]*courier.Courier)
+	stepped := make(map[uuid.UUID]struct{})
+	for _, order := range orders {
+		courierID := order.CourierID()
+		if courierID == nil {
+			return errs.NewValueIsRequiredError("courierID")
+		}
+
+		aggregate, ok := moved[*courierID]
+		if !ok {
+			aggregate, err = uow.CourierRepository().Get(ctx, *courierID)
+			if err != nil {
+				return err
+			}
+			moved[*courierID] = aggregate
+		}
+
+		if !grid.Contains(aggregate.Location()) {
+			if err := h.release(ctx, uow, order, aggregate, "courier is outside the grid"); err != nil {
+				return err
+			}
+			continue
+		}
+		if !grid.Contains(order.Location()) {
+			if err := h.release(ctx, uow, order, aggregate, "order is outside the grid"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, ok := stepped[*courierID]; !ok {
+			stepped[*courierID] = struct{}{}
+
+			err := aggregate.Move(order.Location(), h.cityMap, costs)
+			if errors.Is(err, kernel.ErrNoPath) {
+				if err := h.release(ctx, uow, order, aggregate, "order is unreachable"); err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if !aggregate.Location().Equals(order.Location()) {
+			continue
+		}
+
+		if err := order.Complete(); err != nil {
+			return err
+		}
+		if err := aggregate.CompleteOrder(order); err != nil {
+			return err
+		}
+		if err := uow.OrderRepository().Update(ctx, order); err != nil {
+			return err
+		}
+	}
+
+	for _, aggregate := range moved {
+		if err := uow.CourierRepository().Update(ctx, aggregate); err != nil {
+			return err
+		}
+	}
+
+	return uow.Commit(ctx)
+}
+
+// release снимает с курьера заказ, который он не может доставить, чтобы курьер
+// не оставался занятым навсегда, а заказ мог достаться другому курьеру
+func (h *moveCouriersCommandHandler) release(
+	ctx context.Context, uow ports.UnitOfWork, order *order.Order, aggregate *courier.Courier, reason string) error {
+	h.logger.WarnContext(ctx, "release order",
+		slog.String("order_id", order.ID().String()),
+		slog.String("courier_id", aggregate.ID().String()),
+		slog.String("reason", reason))
+
+	if err := order.Release(); err != nil {
+		return err
+	}
+	if err := aggregate.DropOrder(order); err != nil {
+		return err
+	}
+	return uow.OrderRepository().Update(ctx, order)
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/logging"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveCouriersCommandHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	command, err := NewMoveCouriersCommand()
+	require.NoError(t, err)
+
+	setup := func(t *testing.T, from, to [2]int, speed int) (*fakeUnitOfWork, MoveCouriersCommandHandler, *order.Order, *courier.Courier) {
+		uow := newFakeUnitOfWork()
+		handler, err := NewMoveCouriersCommandHandler(uow, mustCreateCityMap(t), kernel.CostMap{}, logging.Discard())
+		require.NoError(t, err)
+
+		assigned, err := order.NewOrder(uuid.New(), mustCreateLocation(t, to[0], to[1]), 5)
+		require.NoError(t, err)
+		moving, err := courier.NewCourier("Вело", kernel.Bicycle, speed, mustCreateLocation(t, from[0], from[1]))
+		require.NoError(t, err)
+		require.NoError(t, moving.TakeOrder(assigned))
+		require.NoError(t, assigned.Assign(moving.ID()))
+
+		require.NoError(t, uow.OrderRepository().Add(ctx, assigned))
+		require.NoError(t, uow.CourierRepository().Add(ctx, moving))
+
+		return uow, handler, assigned, moving
+	}
+
+	t.Run("moves courier one step toward order", func(t *testing.T) {
+		uow, handler, assigned, moving := setup(t, [2]int{1, 1}, [2]int{5, 5}, 2)
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, mustCreateLocation(t, 3, 1), moving.Location())
+		assert.Equal(t, order.Status(order.Assigned).String(), assigned.Status())
+		assert.Empty(t, uow.orderRepository.updated)
+		assert.Equal(t, []uuid.UUID{moving.ID()}, uow.courierRepository.updated)
+		assert.Equal(t, 1, uow.commits)
+	})
+
+	t.Run("completes order on arrival", func(t *testing.T) {
+		uow, handler, assigned, moving := setup(t, [2]int{4, 5}, [2]int{5, 5}, 2)
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, assigned.Location(), moving.Location())
+		assert.Equal(t, order.Status(order.Completed).String(), assigned.Status())
+		for _, place := range moving.Places() {
+			assert.Nil(t, place.OrderID())
+		}
+		assert.Equal(t, []uuid.UUID{assigned.ID()}, uow.orderRepository.updated)
+		assert.Equal(t, []uuid.UUID{moving.ID()}, uow.courierRepository.updated)
+		assert.Equal(t, 1, uow.commits)
+	})
+
+	t.Run("does nothing without assigned orders", func(t *testing.T) {
+		uow := newFakeUnitOfWork()
+		handler, err := NewMoveCouriersCommandHandler(uow, mustCreateCityMap(t), kernel.CostMap{}, logging.Discard())
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(ctx, command))
+		assert.Equal(t, 0, uow.commits)
+	})
+
+	t.Run("courier without passable path releases order", func(t *testing.T) {
+		uow, _, assigned, moving := setup(t, [2]int{5, 5}, [2]int{1, 1}, 2)
+		enclosed := mustCreateCityMap(t, mustCreateLocation(t, 1, 2), mustCreateLocation(t, 2, 1))
+		handler, err := NewMoveCouriersCommandHandler(uow, enclosed, kernel.CostMap{}, logging.Discard())
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, mustCreateLocation(t, 5, 5), moving.Location())
+		assert.Equal(t, order.Status(order.Created).String(), assigned.Status())
+		assert.Nil(t, assigned.CourierID())
+		for _, place := range moving.Places() {
+			assert.Nil(t, place.OrderID())
+		}
+		assert.Equal(t, []uuid.UUID{assigned.ID()}, uow.orderRepository.updated)
+		assert.Equal(t, []uuid.UUID{moving.ID()}, uow.courierRepository.updated)
+		assert.Equal(t, 1, uow.commits)
+	})
+
+	t.Run("courier slows down in traffic", func(t *testing.T) {
+		uow, _, _, moving := setup(t, [2]int{1, 1}, [2]int{5, 1}, 2)
+		zone, err := kernel.NewTrafficZone("rush hour", mustCreateLocation(t, 2, 1), mustCreateLocation(t, 10, 10),
+			map[kernel.Transport]float64{kernel.Bicycle: 2})
+		require.NoError(t, err)
+		costs, err := kernel.NewCostMap([]kernel.TrafficZone{zone})
+		require.NoError(t, err)
+		handler, err := NewMoveCouriersCommandHandler(uow, mustCreateCityMap(t), costs, logging.Discard())
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, mustCreateLocation(t, 2, 1), moving.Location())
+		assert.Equal(t, 1, uow.commits)
+	})
+
+	t.Run("releases orders outside a shrunk grid", func(t *testing.T) {
+		uow, _, stranded, outside := setup(t, [2]int{8, 8}, [2]int{1, 1}, 2)
+
+		inside, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		require.NoError(t, inside.AddStoragePlace("Рюкзак", 5))
+		farOrder, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 9, 9), 1)
+		require.NoError(t, err)
+		nearOrder, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 3, 1), 1)
+		require.NoError(t, err)
+		for _, assigned := range []*order.Order{farOrder, nearOrder} {
+			require.NoError(t, inside.TakeOrder(assigned))
+			require.NoError(t, assigned.Assign(inside.ID()))
+			require.NoError(t, uow.OrderRepository().Add(ctx, assigned))
+		}
+		require.NoError(t, uow.CourierRepository().Add(ctx, inside))
+
+		grid, err := kernel.NewGridOfSize(5, 5)
+		require.NoError(t, err)
+		shrunk, err := kernel.NewCityMap(grid, nil)
+		require.NoError(t, err)
+		handler, err := NewMoveCouriersCommandHandler(uow, shrunk, kernel.CostMap{}, logging.Discard())
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, mustCreateLocation(t, 8, 8), outside.Location())
+		for _, released := range []*order.Order{stranded, farOrder} {
+			assert.Equal(t, order.Status(order.Created).String(), released.Status())
+			assert.Nil(t, released.CourierID())
+		}
+		for _, place := range outside.Places() {
+			assert.Nil(t, place.OrderID())
+		}
+		assert.Equal(t, mustCreateLocation(t, 2, 1), inside.Location())
+		assert.Equal(t, order.Status(order.Assigned).String(), nearOrder.Status())
+		assert.ElementsMatch(t, []uuid.UUID{stranded.ID(), farOrder.ID()}, uow.orderRepository.updated)
+		assert.ElementsMatch(t, []uuid.UUID{outside.ID(), inside.ID()}, uow.courierRepository.updated)
+		assert.Equal(t, 1, uow.commits)
+	})
+
+	t.Run("fails when courier is missing", func(t *testing.T) {
+		uow, handler, _, moving := setup(t, [2]int{1, 1}, [2]int{5, 5}, 2)
+		delete(uow.courierRepository.couriers, moving.ID())
+
+		assert.Error(t, handler.Handle(ctx, command))
+		assert.Equal(t, 0, uow.commits)
+	})
+}
+
+func TestNewMoveCouriersCommandHandler(t *testing.T) {
+	_, err := NewMoveCouriersCommandHandler(newFakeUnitOfWork(), mustCreateCityMap(t), nil, logging.Discard())
+	assert.Error(t, err)
+
+	_, err = NewMoveCouriersCommandHandler(newFakeUnitOfWork(), mustCreateCityMap(t), kernel.CostMap{}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"delivery/internal/pkg/errs"
+)
+
+type RemoveTrafficZoneCommand struct {
+	name string
+
+	isSet bool
+}
+
+func NewRemoveTrafficZoneCommand(name string) (RemoveTrafficZoneCommand, error) {
+	if name == "" {
+		return RemoveTrafficZoneCommand{}, errs.NewValueIsRequiredError("name")
+	}
+
+	return RemoveTrafficZoneCommand{
+		name: name,
+
+		isSet: true,
+	}, nil
+}
+
+func (c RemoveTrafficZoneCommand) Name() string {
+	return c.name
+}
+
+func (c RemoveTrafficZoneCommand) IsEmpty() bool {
+	return !c.isSet
+}
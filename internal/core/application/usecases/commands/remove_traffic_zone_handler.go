@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/application/costmap"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+)
+
+type RemoveTrafficZoneCommandHandler interface {
+	Handle(ctx context.Context, command RemoveTrafficZoneCommand) error
+}
+
+var _ RemoveTrafficZoneCommandHandler = &removeTrafficZoneCommandHandler{}
+
+type removeTrafficZoneCommandHandler struct {
+	trafficZoneRepository ports.TrafficZoneRepository
+	costMapHolder         *costmap.Holder
+}
+
+func NewRemoveTrafficZoneCommandHandler(
+	trafficZoneRepository ports.TrafficZoneRepository,
+	costMapHolder *costmap.Holder,
+) (RemoveTrafficZoneCommandHandler, error) {
+	if trafficZoneRepository == nil {
+		return nil, errs.NewValueIsRequiredError("trafficZoneRepository")
+	}
+	if costMapHolder == nil {
+		return nil, errs.NewValueIsRequiredError("costMapHolder")
+	}
+
+	return &removeTrafficZoneCommandHandler{
+		trafficZoneRepository: trafficZoneRepository,
+		costMapHolder:         costMapHolder,
+	}, nil
+}
+
+func (h *removeTrafficZoneCommandHandler) Handle(ctx context.Context, command RemoveTrafficZoneCommand) error {
+	if command.IsEmpty() {
+		return errs.NewValueIsRequiredError("command")
+	}
+
+	if err := h.trafficZoneRepository.Remove(ctx, command.Name()); err != nil {
+		return err
+	}
+	return h.costMapHolder.Refresh(ctx)
+}
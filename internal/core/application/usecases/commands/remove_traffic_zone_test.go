@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/application/costmap"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/pkg/errs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRemoveTrafficZoneCommand(t *testing.T) {
+	_, err := NewRemoveTrafficZoneCommand("")
+	assert.ErrorContains(t, err, "name")
+
+	command, err := NewRemoveTrafficZoneCommand("center")
+	require.NoError(t, err)
+	assert.Equal(t, "center", command.Name())
+	assert.False(t, command.IsEmpty())
+}
+
+func TestRemoveTrafficZoneCommandHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	repository := newFakeTrafficZoneRepository()
+	holder, err := costmap.NewHolder(repository)
+	require.NoError(t, err)
+	setHandler, err := NewSetTrafficZoneCommandHandler(repository, kernel.DefaultGrid(), holder)
+	require.NoError(t, err)
+	handler, err := NewRemoveTrafficZoneCommandHandler(repository, holder)
+	require.NoError(t, err)
+
+	set, err := NewSetTrafficZoneCommand("center", 3, 3, 6, 6, map[string]float64{"car": 2})
+	require.NoError(t, err)
+	require.NoError(t, setHandler.Handle(ctx, set))
+
+	command, err := NewRemoveTrafficZoneCommand("center")
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(ctx, command))
+
+	assert.Empty(t, repository.zones)
+	assert.True(t, holder.CostMap().IsEmpty())
+	assert.ErrorIs(t, handler.Handle(ctx, command), errs.ErrObjectNotFound)
+}
+
+func TestNewRemoveTrafficZoneCommandHandler(t *testing.T) {
+	holder, err := costmap.NewHolder(newFakeTrafficZoneRepository())
+	require.NoError(t, err)
+
+	_, err = NewRemoveTrafficZoneCommandHandler(nil, holder)
+	assert.Error(t, err)
+	_, err = NewRemoveTrafficZoneCommandHandler(newFakeTrafficZoneRepository(), nil)
+	assert.Error(t, err)
+}
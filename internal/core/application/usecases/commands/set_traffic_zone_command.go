@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"delivery/internal/pkg/errs"
+	"maps"
+)
+
+// SetTrafficZoneCommand создает район пробок или заменяет район с тем же
+// именем. Множители задаются по названию транспорта: pedestrian, bicycle, car.
+type SetTrafficZoneCommand struct {
+	name        string
+	fromX       int
+	fromY       int
+	toX         int
+	toY         int
+	multipliers map[string]float64
+
+	isSet bool
+}
+
+func NewSetTrafficZoneCommand(name string, fromX, fromY, toX, toY int, multipliers map[string]float64) (SetTrafficZoneCommand, error) {
+	if name == "" {
+		return SetTrafficZoneCommand{}, errs.NewValueIsRequiredError("name")
+	}
+	if len(multipliers) == 0 {
+		return SetTrafficZoneCommand{}, errs.NewValueIsRequiredError("multipliers")
+	}
+
+	return SetTrafficZoneCommand{
+		name:        name,
+		fromX:       fromX,
+		fromY:       fromY,
+		toX:         toX,
+		toY:         toY,
+		multipliers: maps.Clone(multipliers),
+
+		isSet: true,
+	}, nil
+}
+
+func (c SetTrafficZoneCommand) Name() string {
+	return c.name
+}
+
+func (c SetTrafficZoneCommand) FromX() int {
+	return c.fromX
+}
+
+func (c SetTrafficZoneCommand) FromY() int {
+	return c.fromY
+}
+
+func (c SetTrafficZoneCommand) ToX() int {
+	return c.toX
+}
+
+func (c SetTrafficZoneCommand) ToY() int {
+	return c.toY
+}
+
+func (c SetTrafficZoneCommand) Multipliers() map[string]float64 {
+	return maps.Clone(c.multipliers)
+}
+
+func (c SetTrafficZoneCommand) IsEmpty() bool {
+	return !c.isSet
+}
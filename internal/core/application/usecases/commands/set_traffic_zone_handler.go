@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/application/costmap"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+)
+
+type SetTrafficZoneCommandHandler interface {
+	Handle(ctx context.Context, command SetTrafficZoneCommand) error
+}
+
+var _ SetTrafficZoneCommandHandler = &setTrafficZoneCommandHandler{}
+
+type setTrafficZoneCommandHandler struct {
+	trafficZoneRepository ports.TrafficZoneRepository
+	grid                  kernel.Grid
+	costMapHolder         *costmap.Holder
+}
+
+func NewSetTrafficZoneCommandHandler(
+	trafficZoneRepository ports.TrafficZoneRepository,
+	grid kernel.Grid,
+	costMapHolder *costmap.Holder,
+) (SetTrafficZoneCommandHandler, error) {
+	if trafficZoneRepository == nil {
+		return nil, errs.NewValueIsRequiredError("trafficZoneRepository")
+	}
+	if grid.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("grid")
+	}
+	if costMapHolder == nil {
+		return nil, errs.NewValueIsRequiredError("costMapHolder")
+	}
+
+	return &setTrafficZoneCommandHandler{
+		trafficZoneRepository: trafficZoneRepository,
+		grid:                  grid,
+		costMapHolder:         costMapHolder,
+	}, nil
+}
+
+// Handle сохраняет район и сразу обновляет карту стоимости этого экземпляра.
+// Остальные экземпляры подхватят район при очередном обновлении карты.
+func (h *setTrafficZoneCommandHandler) Handle(ctx context.Context, command SetTrafficZoneCommand) error {
+	if command.IsEmpty() {
+		return errs.NewValueIsRequiredError("command")
+	}
+
+	from, err := kernel.NewLocation(h.grid, command.FromX(), command.FromY())
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("from", err)
+	}
+	to, err := kernel.NewLocation(h.grid, command.ToX(), command.ToY())
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("to", err)
+	}
+
+	multipliers := make(map[kernel.Transport]float64, len(command.Multipliers()))
+	for name, multiplier := range command.Multipliers() {
+		transport, err := kernel.ParseTransport(name)
+		if err != nil {
+			return err
+		}
+		multipliers[transport] = multiplier
+	}
+
+	zone, err := kernel.NewTrafficZone(command.Name(), from, to, multipliers)
+	if err != nil {
+		return err
+	}
+
+	if err := h.trafficZoneRepository.Save(ctx, zone); err != nil {
+		return err
+	}
+	return h.costMapHolder.Refresh(ctx)
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/application/costmap"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/pkg/errs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSetTrafficZoneCommand(t *testing.T) {
+	_, err := NewSetTrafficZoneCommand("", 1, 1, 2, 2, map[string]float64{"car": 2})
+	assert.ErrorContains(t, err, "name")
+
+	_, err = NewSetTrafficZoneCommand("center", 1, 1, 2, 2, nil)
+	assert.ErrorContains(t, err, "multipliers")
+
+	command, err := NewSetTrafficZoneCommand("center", 1, 2, 3, 4, map[string]float64{"car": 2})
+	require.NoError(t, err)
+	assert.Equal(t, "center", command.Name())
+	assert.Equal(t, []int{1, 2, 3, 4}, []int{command.FromX(), command.FromY(), command.ToX(), command.ToY()})
+	assert.Equal(t, map[string]float64{"car": 2}, command.Multipliers())
+	assert.False(t, command.IsEmpty())
+}
+
+func TestSetTrafficZoneCommandHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	repository := newFakeTrafficZoneRepository()
+	holder, err := costmap.NewHolder(repository)
+	require.NoError(t, err)
+	handler, err := NewSetTrafficZoneCommandHandler(repository, kernel.DefaultGrid(), holder)
+	require.NoError(t, err)
+
+	t.Run("applies zone immediately", func(t *testing.T) {
+		command, err := NewSetTrafficZoneCommand("center", 3, 3, 6, 6, map[string]float64{"car": 2.5})
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		require.Contains(t, repository.zones, "center")
+		location, err := kernel.NewLocation(kernel.DefaultGrid(), 4, 4)
+		require.NoError(t, err)
+		assert.Equal(t, 2.5, holder.CostMap().StepCost(location, kernel.Car))
+		assert.Equal(t, 1.0, holder.CostMap().StepCost(location, kernel.Pedestrian))
+	})
+
+	t.Run("rejects invalid zone", func(t *testing.T) {
+		outside, err := NewSetTrafficZoneCommand("outside", 3, 3, 11, 6, map[string]float64{"car": 2})
+		require.NoError(t, err)
+		assert.ErrorIs(t, handler.Handle(ctx, outside), errs.ErrValueIsInvalid)
+
+		unknown, err := NewSetTrafficZoneCommand("unknown", 3, 3, 6, 6, map[string]float64{"truck": 2})
+		require.NoError(t, err)
+		assert.ErrorIs(t, handler.Handle(ctx, unknown), errs.ErrValueIsInvalid)
+
+		faster, err := NewSetTrafficZoneCommand("faster", 3, 3, 6, 6, map[string]float64{"car": 0.5})
+		require.NoError(t, err)
+		assert.ErrorIs(t, handler.Handle(ctx, faster), errs.ErrValueIsOutOfRange)
+
+		assert.Len(t, repository.zones, 1)
+	})
+
+	t.Run("rejects empty command", func(t *testing.T) {
+		assert.ErrorIs(t, handler.Handle(ctx, SetTrafficZoneCommand{}), errs.ErrValueIsRequired)
+	})
+}
+
+func TestNewSetTrafficZoneCommandHandler(t *testing.T) {
+	repository := newFakeTrafficZoneRepository()
+	holder, err := costmap.NewHolder(repository)
+	require.NoError(t, err)
+
+	_, err = NewSetTrafficZoneCommandHandler(nil, kernel.DefaultGrid(), holder)
+	assert.Error(t, err)
+	_, err = NewSetTrafficZoneCommandHandler(repository, kernel.Grid{}, holder)
+	assert.Error(t, err)
+	_, err = NewSetTrafficZoneCommandHandler(repository, kernel.DefaultGrid(), nil)
+	assert.Error(t, err)
+}
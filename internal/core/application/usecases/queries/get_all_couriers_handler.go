@@ -0,0 +1,77 @@
+package queries
+
+import (
+	"context"
+	"delivery/internal/pkg/errs"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GetAllCouriersQueryHandler interface {
+	Handle(ctx context.Context, query GetAllCouriersQuery) (GetAllCouriersResponse, error)
+}
+
+type GetAllCouriersResponse struct {
+	Couriers []CourierResponse
+}
+
+type CourierResponse struct {
+	ID        uuid.UUID
+	Name      string
+	Transport string
+	Location  LocationResponse
+}
+
+type LocationResponse struct {
+	X int
+	Y int
+}
+
+var _ GetAllCouriersQueryHandler = &getAllCouriersQueryHandler{}
+
+type getAllCouriersQueryHandler struct {
+	db *gorm.DB
+}
+
+func NewGetAllCouriersQueryHandler(db *gorm.DB) (GetAllCouriersQueryHandler, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &getAllCouriersQueryHandler{
+		db: db,
+	}, nil
+}
+
+func (h *getAllCouriersQueryHandler) Handle(ctx context.Context, query GetAllCouriersQuery) (GetAllCouriersResponse, error) {
+	if query.IsEmpty() {
+		return GetAllCouriersResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	var rows []struct {
+		ID        uuid.UUID
+		Name      string
+		Transport string
+		LocationX int
+		LocationY int
+	}
+	err := h.db.WithContext(ctx).
+		Raw("SELECT id, name, transport, location_x, location_y FROM couriers ORDER BY name, id").
+		Scan(&rows).Error
+	if err != nil {
+		return GetAllCouriersResponse{}, err
+	}
+
+	couriers := make([]CourierResponse, 0, len(rows))
+	for _, row := range rows {
+		couriers = append(couriers, CourierResponse{
+			ID:        row.ID,
+			Name:      row.Name,
+			Transport: row.Transport,
+			Location:  LocationResponse{X: row.LocationX, Y: row.LocationY},
+		})
+	}
+
+	return GetAllCouriersResponse{Couriers: couriers}, nil
+}
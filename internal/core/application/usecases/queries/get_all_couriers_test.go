@@ -0,0 +1,67 @@
+package queries
+
+import (
+	"context"
+	"delivery/internal/adapters/out/postgres"
+	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/testcnts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupTest(t *testing.T) (context.Context, *gorm.DB, ports.UnitOfWork) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+	container, dsn, err := testcnts.StartPostgresContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	db, err := gorm.Open(gormpostgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	uow, err := postgres.NewUnitOfWork(db, clock.System())
+	require.NoError(t, err)
+
+	return ctx, db, uow
+}
+
+func mustCreateLocation(t *testing.T, x, y int) kernel.Location {
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), x, y)
+	require.NoError(t, err)
+	return location
+}
+
+func TestGetAllCouriersQueryHandler_Handle(t *testing.T) {
+	ctx, db, uow := setupTest(t)
+
+	walker, err := courier.NewCourier("Пеший", kernel.Pedestrian, 1, mustCreateLocation(t, 1, 2))
+	require.NoError(t, err)
+	require.NoError(t, uow.CourierRepository().Add(ctx, walker))
+
+	handler, err := NewGetAllCouriersQueryHandler(db)
+	require.NoError(t, err)
+	query, err := NewGetAllCouriersQuery()
+	require.NoError(t, err)
+
+	response, err := handler.Handle(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, response.Couriers, 1)
+	assert.Equal(t, walker.ID(), response.Couriers[0].ID)
+	assert.Equal(t, "Пеший", response.Couriers[0].Name)
+	assert.Equal(t, "pedestrian", response.Couriers[0].Transport)
+	assert.Equal(t, LocationResponse{X: 1, Y: 2}, response.Couriers[0].Location)
+}
+
+func TestNewGetAllCouriersQueryHandler(t *testing.T) {
+	_, err := NewGetAllCouriersQueryHandler(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package queries
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/errs"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GetNotCompletedOrdersQueryHandler interface {
+	Handle(ctx context.Context, query GetNotCompletedOrdersQuery) (GetNotCompletedOrdersResponse, error)
+}
+
+type GetNotCompletedOrdersResponse struct {
+	Orders []OrderResponse
+}
+
+type OrderResponse struct {
+	ID       uuid.UUID
+	Location LocationResponse
+}
+
+var _ GetNotCompletedOrdersQueryHandler = &getNotCompletedOrdersQueryHandler{}
+
+type getNotCompletedOrdersQueryHandler struct {
+	db *gorm.DB
+}
+
+func NewGetNotCompletedOrdersQueryHandler(db *gorm.DB) (GetNotCompletedOrdersQueryHandler, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &getNotCompletedOrdersQueryHandler{
+		db: db,
+	}, nil
+}
+
+func (h *getNotCompletedOrdersQueryHandler) Handle(
+	ctx context.Context, query GetNotCompletedOrdersQuery) (GetNotCompletedOrdersResponse, error) {
+	if query.IsEmpty() {
+		return GetNotCompletedOrdersResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	var rows []struct {
+		ID        uuid.UUID
+		LocationX int
+		LocationY int
+	}
+	err := h.db.WithContext(ctx).
+		Raw("SELECT id, location_x, location_y FROM orders WHERE status IN ? ORDER BY created_at",
+			[]string{order.Status(order.Created).String(), order.Status(order.Assigned).String()}).
+		Scan(&rows).Error
+	if err != nil {
+		return GetNotCompletedOrdersResponse{}, err
+	}
+
+	orders := make([]OrderResponse, 0, len(rows))
+	for _, row := range rows {
+		orders = append(orders, OrderResponse{
+			ID:       row.ID,
+			Location: LocationResponse{X: row.LocationX, Y: row.LocationY},
+		})
+	}
+
+	return GetNotCompletedOrdersResponse{Orders: orders}, nil
+}
@@ -0,0 +1,47 @@
+package queries
+
+import (
+	"delivery/internal/core/domain/models/order"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotCompletedOrdersQueryHandler_Handle(t *testing.T) {
+	ctx, db, uow := setupTest(t)
+
+	created, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 3, 4), 5)
+	require.NoError(t, err)
+	assigned, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 5, 6), 5)
+	require.NoError(t, err)
+	require.NoError(t, assigned.Assign(uuid.New()))
+	completed, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 7, 8), 5)
+	require.NoError(t, err)
+	require.NoError(t, completed.Assign(uuid.New()))
+	require.NoError(t, completed.Complete())
+
+	for _, aggregate := range []*order.Order{created, assigned, completed} {
+		require.NoError(t, uow.OrderRepository().Add(ctx, aggregate))
+	}
+
+	handler, err := NewGetNotCompletedOrdersQueryHandler(db)
+	require.NoError(t, err)
+	query, err := NewGetNotCompletedOrdersQuery()
+	require.NoError(t, err)
+
+	response, err := handler.Handle(ctx, query)
+	require.NoError(t, err)
+
+	ids := make([]uuid.UUID, 0, len(response.Orders))
+	for _, o := range response.Orders {
+		ids = append(ids, o.ID)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{created.ID(), assigned.ID()}, ids)
+}
+
+func TestNewGetNotCompletedOrdersQueryHandler(t *testing.T) {
+	_, err := NewGetNotCompletedOrdersQueryHandler(nil)
+	assert.Error(t, err)
+}
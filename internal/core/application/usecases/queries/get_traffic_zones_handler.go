@@ -0,0 +1,79 @@
+package queries
+
+import (
+	"context"
+	"delivery/internal/pkg/errs"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type GetTrafficZonesQueryHandler interface {
+	Handle(ctx context.Context, query GetTrafficZonesQuery) (GetTrafficZonesResponse, error)
+}
+
+type GetTrafficZonesResponse struct {
+	TrafficZones []TrafficZoneResponse
+}
+
+type TrafficZoneResponse struct {
+	Name        string
+	From        LocationResponse
+	To          LocationResponse
+	Multipliers map[string]float64
+}
+
+var _ GetTrafficZonesQueryHandler = &getTrafficZonesQueryHandler{}
+
+type getTrafficZonesQueryHandler struct {
+	db *gorm.DB
+}
+
+func NewGetTrafficZonesQueryHandler(db *gorm.DB) (GetTrafficZonesQueryHandler, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &getTrafficZonesQueryHandler{
+		db: db,
+	}, nil
+}
+
+func (h *getTrafficZonesQueryHandler) Handle(ctx context.Context, query GetTrafficZonesQuery) (GetTrafficZonesResponse, error) {
+	if query.IsEmpty() {
+		return GetTrafficZonesResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	var rows []struct {
+		Name        string
+		FromX       int
+		FromY       int
+		ToX         int
+		ToY         int
+		Multipliers string
+	}
+	err := h.db.WithContext(ctx).
+		Raw("SELECT name, from_x, from_y, to_x, to_y, multipliers FROM traffic_zones ORDER BY name").
+		Scan(&rows).Error
+	if err != nil {
+		return GetTrafficZonesResponse{}, err
+	}
+
+	zones := make([]TrafficZoneResponse, 0, len(rows))
+	for _, row := range rows {
+		var multipliers map[string]float64
+		if err := json.Unmarshal([]byte(row.Multipliers), &multipliers); err != nil {
+			return GetTrafficZonesResponse{}, fmt.Errorf("traffic zone %s: %w", row.Name, err)
+		}
+
+		zones = append(zones, TrafficZoneResponse{
+			Name:        row.Name,
+			From:        LocationResponse{X: row.FromX, Y: row.FromY},
+			To:          LocationResponse{X: row.ToX, Y: row.ToY},
+			Multipliers: multipliers,
+		})
+	}
+
+	return GetTrafficZonesResponse{TrafficZones: zones}, nil
+}
@@ -0,0 +1,13 @@
+package queries
+
+type GetTrafficZonesQuery struct {
+	isSet bool
+}
+
+func NewGetTrafficZonesQuery() (GetTrafficZonesQuery, error) {
+	return GetTrafficZonesQuery{isSet: true}, nil
+}
+
+func (q GetTrafficZonesQuery) IsEmpty() bool {
+	return !q.isSet
+}
@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"delivery/internal/adapters/out/postgres/trafficzonerepo"
+	"delivery/internal/core/domain/models/kernel"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrafficZonesQueryHandler_Handle(t *testing.T) {
+	ctx, db, _ := setupTest(t)
+
+	repository, err := trafficzonerepo.NewRepository(db)
+	require.NoError(t, err)
+	zone, err := kernel.NewTrafficZone("center", mustCreateLocation(t, 3, 3), mustCreateLocation(t, 6, 6),
+		map[kernel.Transport]float64{kernel.Car: 2.5})
+	require.NoError(t, err)
+	require.NoError(t, repository.Save(ctx, zone))
+
+	handler, err := NewGetTrafficZonesQueryHandler(db)
+	require.NoError(t, err)
+	query, err := NewGetTrafficZonesQuery()
+	require.NoError(t, err)
+
+	response, err := handler.Handle(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []TrafficZoneResponse{{
+		Name:        "center",
+		From:        LocationResponse{X: 3, Y: 3},
+		To:          LocationResponse{X: 6, Y: 6},
+		Multipliers: map[string]float64{"car": 2.5},
+	}}, response.TrafficZones)
+}
+
+func TestNewGetTrafficZonesQueryHandler(t *testing.T) {
+	_, err := NewGetTrafficZonesQueryHandler(nil)
+	assert.Error(t, err)
+}
@@ -3,9 +3,9 @@ package courier
 import (
 	"delivery/internal/core/domain/models/kernel"
 	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/ddd"
 	"delivery/internal/pkg/errs"
 	"errors"
-	"math"
 
 	"github.com/google/uuid"
 )
@@ -14,27 +14,41 @@ var (
 	ErrInvalidCourierID = errors.New("courier id cannot be nil")
 	ErrInvalidName      = errors.New("name cannot be empty")
 	ErrInvalidSpeed     = errors.New("speed must be positive")
+	ErrInvalidTransport = errors.New("transport is unknown")
 	ErrOrderNotFound    = errors.New("order not found")
 )
 
 const (
 	defaultStorageName   = "Cумка"
 	defaultStorageVolume = 10
+
+	// budgetTolerance гасит ошибку округления, которая копится при сложении
+	// дробных стоимостей клеток
+	budgetTolerance = 1e-9
 )
 
 type Courier struct {
-	id       uuid.UUID
-	name     string
-	speed    int
-	location kernel.Location
-	places   []*StoragePlace
+	*ddd.BaseAggregate[uuid.UUID]
+	name      string
+	transport kernel.Transport
+	speed     int
+	location  kernel.Location
+	places    []*StoragePlace
+
+	// movementBudget — неистраченный запас хода прошлых шагов: клетка дороже
+	// скорости курьера проходится за несколько шагов
+	movementBudget float64
 }
 
-func NewCourier(name string, speed int, location kernel.Location) (*Courier, error) {
+func NewCourier(name string, transport kernel.Transport, speed int, location kernel.Location) (*Courier, error) {
 	if name == "" {
 		return nil, ErrInvalidName
 	}
 
+	if !transport.IsValid() {
+		return nil, ErrInvalidTransport
+	}
+
 	if speed <= 0 {
 		return nil, ErrInvalidSpeed
 	}
@@ -44,18 +58,31 @@ func NewCourier(name string, speed int, location kernel.Location) (*Courier, err
 		return nil, err
 	}
 
-	return &Courier{
-		id:       uuid.New(),
-		name:     name,
-		speed:    speed,
-		location: location,
-		places:   []*StoragePlace{defaultStorage},
-	}, nil
+	courier := &Courier{
+		BaseAggregate: ddd.NewBaseAggregate(uuid.New()),
+		name:          name,
+		transport:     transport,
+		speed:         speed,
+		location:      location,
+		places:        []*StoragePlace{defaultStorage},
+	}
+	courier.RaiseDomainEvent(NewCourierCreatedDomainEvent(courier))
+	courier.RaiseDomainEvent(NewStoragePlaceAddedDomainEvent(courier, defaultStorage))
+	return courier, nil
 
 }
 
-func (c *Courier) ID() uuid.UUID {
-	return c.id
+func RestoreCourier(id uuid.UUID, name string, transport kernel.Transport, speed int, location kernel.Location,
+	movementBudget float64, places []*StoragePlace, version int) *Courier {
+	return &Courier{
+		BaseAggregate:  ddd.RestoreBaseAggregate(id, version),
+		name:           name,
+		transport:      transport,
+		speed:          speed,
+		location:       location,
+		places:         places,
+		movementBudget: movementBudget,
+	}
 }
 
 func (c *Courier) Name() string {
@@ -66,10 +93,20 @@ func (c *Courier) Speed() int {
 	return c.speed
 }
 
+// Transport — вид транспорта курьера, от него зависит стоимость шага в пробках
+func (c *Courier) Transport() kernel.Transport {
+	return c.transport
+}
+
 func (c *Courier) Location() kernel.Location {
 	return c.location
 }
 
+// MovementBudget — запас хода, накопленный к следующей клетке маршрута
+func (c *Courier) MovementBudget() float64 {
+	return c.movementBudget
+}
+
 func (c *Courier) Places() []*StoragePlace {
 	return c.places
 }
@@ -81,6 +118,7 @@ func (c *Courier) AddStoragePlace(name string, volume int) error {
 	}
 
 	c.places = append(c.places, storagePlace)
+	c.RaiseDomainEvent(NewStoragePlaceAddedDomainEvent(c, storagePlace))
 	return nil
 }
 
@@ -107,7 +145,11 @@ func (c *Courier) TakeOrder(order *order.Order) error {
 
 	for _, place := range c.Places() {
 		if ok, err := place.CanStore(order.Volume()); err == nil && ok {
-			return place.Store(order.ID(), order.Volume())
+			if err := place.Store(order.ID(), order.Volume()); err != nil {
+				return err
+			}
+			c.RaiseDomainEvent(NewOrderTakenDomainEvent(c, order.ID(), place))
+			return nil
 		}
 	}
 
@@ -122,7 +164,11 @@ func (c *Courier) CompleteOrder(order *order.Order) error {
 
 	for _, place := range c.Places() {
 		if place.OrderID() != nil && order.ID() == *place.OrderID() {
-			return place.Clear(order.ID())
+			if err := place.Clear(order.ID()); err != nil {
+				return err
+			}
+			c.RaiseDomainEvent(NewOrderDeliveredDomainEvent(c, order.ID(), place))
+			return nil
 		}
 	}
 
@@ -130,46 +176,92 @@ func (c *Courier) CompleteOrder(order *order.Order) error {
 
 }
 
-func (c *Courier) CalculateTimeToLocation(location kernel.Location) (float64, error) {
+// DropOrder освобождает место хранения заказа, до которого курьер не может
+// добраться, и сбрасывает накопленный к нему запас хода
+func (c *Courier) DropOrder(order *order.Order) error {
+	if order == nil {
+		return errs.NewValueIsRequiredError("order")
+	}
+
+	place, err := c.findStoragePlaceByOrderID(order.ID())
+	if err != nil {
+		return err
+	}
+	if place == nil {
+		return ErrOrderNotFound
+	}
+	if err := place.Clear(order.ID()); err != nil {
+		return err
+	}
+	c.movementBudget = 0
+	c.RaiseDomainEvent(NewOrderDroppedDomainEvent(c, order.ID(), place))
+	return nil
+}
+
+// CalculateTimeToLocation возвращает число шагов до location при заданной мере
+// расстояния: по сетке, по маршруту с учетом пробок или по прямой между
+// клетками, спроецированными на карту. Запас хода
+// учитывается так же, как в Move, поэтому с PathDistance курьер доходит ровно
+// за столько шагов, сколько дает округление результата вверх.
+func (c *Courier) CalculateTimeToLocation(location kernel.Location, distance kernel.Distance) (float64, error) {
 	if location.IsEmpty() {
 		return 0, errs.NewValueIsRequiredError("location")
 	}
+	if distance == nil {
+		return 0, errs.NewValueIsRequiredError("distance")
+	}
 
-	distance, err := location.DistanceTo(c.Location())
+	cells, err := distance.Between(c.Location(), location, c.Transport())
 	if err != nil {
 		return 0, err
 	}
-	return float64(distance) / float64(c.Speed()), nil
+	return max(cells-c.movementBudget, 0) / float64(c.Speed()), nil
 }
 
-func (c *Courier) Move(target kernel.Location) error {
+// Move делает один шаг к target по самому дешевому проходимому маршруту карты.
+// За шаг курьер получает запас хода, равный скорости, и тратит его на клетки:
+// клетка без пробок стоит 1, в пробке — множитель района для его транспорта.
+// Остаток переходит на следующий шаг, поэтому дорогую клетку курьер проходит
+// за несколько шагов, а на месте назначения остаток сгорает. Если маршрута
+// нет, курьер остается на месте, а Move возвращает kernel.ErrNoPath.
+func (c *Courier) Move(target kernel.Location, cityMap kernel.CityMap, costs kernel.CostMap) error {
 	if target.IsEmpty() {
 		return errs.NewValueIsRequiredError("location")
 	}
-
-	dx := float64(target.X() - c.location.X())
-	dy := float64(target.Y() - c.location.Y())
-	remainingRange := float64(c.speed)
-
-	if math.Abs(dx) > remainingRange {
-		dx = math.Copysign(remainingRange, dx)
+	if cityMap.IsEmpty() {
+		return errs.NewValueIsRequiredError("cityMap")
 	}
-	remainingRange -= math.Abs(dx)
-
-	if math.Abs(dy) > remainingRange {
-		dy = math.Copysign(remainingRange, dy)
+	if !cityMap.Grid().Contains(target) {
+		return errs.NewValueIsInvalidError("location")
 	}
 
-	newX := c.location.X() + int(dx)
-	newY := c.location.Y() + int(dy)
-
-	newLocation, err := kernel.NewLocation(newX, newY)
+	path, err := cityMap.FindRoute(c.location, target, costs, c.Transport())
 	if err != nil {
 		return err
 	}
+	if path.Length() == 0 {
+		c.movementBudget = 0
+		return nil
+	}
 
-	c.location = newLocation
+	from := c.location
+	budget := c.movementBudget + float64(c.speed)
+	for _, location := range path {
+		cost := costs.StepCost(location, c.Transport())
+		if cost > budget+budgetTolerance {
+			break
+		}
+		budget = max(budget-cost, 0)
+		c.location = location
+	}
+	if c.location.Equals(target) {
+		budget = 0
+	}
+	c.movementBudget = budget
 
+	if !c.location.Equals(from) {
+		c.RaiseDomainEvent(NewCourierMovedDomainEvent(c, from))
+	}
 	return nil
 }
 
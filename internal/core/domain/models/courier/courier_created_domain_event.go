@@ -0,0 +1,38 @@
+package courier
+
+import (
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &CourierCreatedDomainEvent{}
+
+// CourierCreatedDomainEvent — курьер зарегистрирован в системе
+type CourierCreatedDomainEvent struct {
+	ID        uuid.UUID
+	CourierID uuid.UUID
+	Name      string
+	Transport string
+	Speed     int
+	Location  EventLocation
+}
+
+func NewCourierCreatedDomainEvent(aggregate *Courier) *CourierCreatedDomainEvent {
+	return &CourierCreatedDomainEvent{
+		ID:        uuid.New(),
+		CourierID: aggregate.ID(),
+		Name:      aggregate.Name(),
+		Transport: aggregate.Transport().String(),
+		Speed:     aggregate.Speed(),
+		Location:  newEventLocation(aggregate.Location()),
+	}
+}
+
+func (e *CourierCreatedDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *CourierCreatedDomainEvent) GetName() string {
+	return "CourierCreatedDomainEvent"
+}
@@ -0,0 +1,35 @@
+package courier
+
+import (
+	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &CourierMovedDomainEvent{}
+
+// CourierMovedDomainEvent — курьер сделал шаг к цели
+type CourierMovedDomainEvent struct {
+	ID        uuid.UUID
+	CourierID uuid.UUID
+	From      EventLocation
+	To        EventLocation
+}
+
+func NewCourierMovedDomainEvent(aggregate *Courier, from kernel.Location) *CourierMovedDomainEvent {
+	return &CourierMovedDomainEvent{
+		ID:        uuid.New(),
+		CourierID: aggregate.ID(),
+		From:      newEventLocation(from),
+		To:        newEventLocation(aggregate.Location()),
+	}
+}
+
+func (e *CourierMovedDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *CourierMovedDomainEvent) GetName() string {
+	return "CourierMovedDomainEvent"
+}
@@ -3,6 +3,11 @@ package courier
 import (
 	"delivery/internal/core/domain/models/kernel"
 	"delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/outbox"
+	"math"
+	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
@@ -13,49 +18,63 @@ import (
 
 func TestNewCourier(t *testing.T) {
 	tests := []struct {
-		name     string
-		nameVal  string
-		speed    int
-		location kernel.Location
-		wantErr  bool
-		errMsg   string
+		name      string
+		nameVal   string
+		transport kernel.Transport
+		speed     int
+		location  kernel.Location
+		wantErr   bool
+		errMsg    string
 	}{
 		{
-			name:     "valid courier",
-			nameVal:  "Test Courier",
-			speed:    10,
-			location: mustCreateLocation(t, 5, 5),
-			wantErr:  false,
+			name:      "valid courier",
+			nameVal:   "Test Courier",
+			transport: kernel.Car,
+			speed:     10,
+			location:  mustCreateLocation(t, 5, 5),
+			wantErr:   false,
 		},
 		{
-			name:     "empty name",
-			nameVal:  "",
-			speed:    10,
-			location: mustCreateLocation(t, 5, 5),
-			wantErr:  true,
-			errMsg:   "name cannot be empty",
+			name:      "empty name",
+			nameVal:   "",
+			transport: kernel.Car,
+			speed:     10,
+			location:  mustCreateLocation(t, 5, 5),
+			wantErr:   true,
+			errMsg:    "name cannot be empty",
 		},
 		{
-			name:     "zero speed",
-			nameVal:  "Test Courier",
-			speed:    0,
-			location: mustCreateLocation(t, 5, 5),
-			wantErr:  true,
-			errMsg:   "speed must be positive",
+			name:      "unknown transport",
+			nameVal:   "Test Courier",
+			transport: kernel.Transport(42),
+			speed:     10,
+			location:  mustCreateLocation(t, 5, 5),
+			wantErr:   true,
+			errMsg:    "transport is unknown",
 		},
 		{
-			name:     "negative speed",
-			nameVal:  "Test Courier",
-			speed:    -5,
-			location: mustCreateLocation(t, 5, 5),
-			wantErr:  true,
-			errMsg:   "speed must be positive",
+			name:      "zero speed",
+			nameVal:   "Test Courier",
+			transport: kernel.Car,
+			speed:     0,
+			location:  mustCreateLocation(t, 5, 5),
+			wantErr:   true,
+			errMsg:    "speed must be positive",
+		},
+		{
+			name:      "negative speed",
+			nameVal:   "Test Courier",
+			transport: kernel.Car,
+			speed:     -5,
+			location:  mustCreateLocation(t, 5, 5),
+			wantErr:   true,
+			errMsg:    "speed must be positive",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			courier, err := NewCourier(tt.nameVal, tt.speed, tt.location)
+			courier, err := NewCourier(tt.nameVal, tt.transport, tt.speed, tt.location)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -67,6 +86,7 @@ func TestNewCourier(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, courier)
 				assert.Equal(t, tt.nameVal, courier.Name())
+				assert.Equal(t, tt.transport, courier.Transport())
 				assert.Equal(t, tt.speed, courier.Speed())
 				assert.Equal(t, tt.location, courier.Location())
 				assert.NotEqual(t, uuid.Nil, courier.ID())
@@ -84,7 +104,7 @@ func TestNewCourier_StoragePlaceCreationError(t *testing.T) {
 	// and we're passing valid ones in NewCourier, this path is hard to test
 	// without modifying the code. This is a limitation of the current design.
 
-	courier, err := NewCourier("Test Courier", 10, mustCreateLocation(t, 5, 5))
+	courier, err := NewCourier("Test Courier", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 	assert.NoError(t, err)
 	assert.NotNil(t, courier)
 }
@@ -94,7 +114,7 @@ func TestCourier_Getters(t *testing.T) {
 	speed := 15
 	location := mustCreateLocation(t, 3, 7)
 
-	courier, err := NewCourier(name, speed, location)
+	courier, err := NewCourier(name, kernel.Car, speed, location)
 	require.NoError(t, err)
 
 	t.Run("ID", func(t *testing.T) {
@@ -105,6 +125,10 @@ func TestCourier_Getters(t *testing.T) {
 		assert.Equal(t, name, courier.Name())
 	})
 
+	t.Run("Transport", func(t *testing.T) {
+		assert.Equal(t, kernel.Car, courier.Transport())
+	})
+
 	t.Run("Speed", func(t *testing.T) {
 		assert.Equal(t, speed, courier.Speed())
 	})
@@ -122,7 +146,7 @@ func TestCourier_Getters(t *testing.T) {
 }
 
 func TestCourier_AddStoragePlace(t *testing.T) {
-	courier, err := NewCourier("Test Courier", 10, mustCreateLocation(t, 5, 5))
+	courier, err := NewCourier("Test Courier", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 	require.NoError(t, err)
 
 	t.Run("add valid storage place", func(t *testing.T) {
@@ -151,7 +175,7 @@ func TestCourier_AddStoragePlace(t *testing.T) {
 	})
 
 	t.Run("add multiple storage places", func(t *testing.T) {
-		courier, _ := NewCourier("Test Courier", 10, mustCreateLocation(t, 5, 5))
+		courier, _ := NewCourier("Test Courier", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 
 		err := courier.AddStoragePlace("Backpack", 3)
 		assert.NoError(t, err)
@@ -164,7 +188,7 @@ func TestCourier_AddStoragePlace(t *testing.T) {
 }
 
 func TestCourier_CanTakeOrder(t *testing.T) {
-	courier, err := NewCourier("Test Courier", 10, mustCreateLocation(t, 5, 5))
+	courier, err := NewCourier("Test Courier", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 	require.NoError(t, err)
 
 	// Add additional storage place
@@ -202,7 +226,7 @@ func TestCourier_CanTakeOrder(t *testing.T) {
 }
 
 func TestCourier_TakeOrder(t *testing.T) {
-	courier, err := NewCourier("Test Courier", 10, mustCreateLocation(t, 5, 5))
+	courier, err := NewCourier("Test Courier", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 	require.NoError(t, err)
 
 	t.Run("take order successfully", func(t *testing.T) {
@@ -234,7 +258,7 @@ func TestCourier_TakeOrder(t *testing.T) {
 }
 
 func TestCourier_CompleteOrder(t *testing.T) {
-	courier, err := NewCourier("Test Courier", 10, mustCreateLocation(t, 5, 5))
+	courier, err := NewCourier("Test Courier", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 	require.NoError(t, err)
 
 	t.Run("complete order successfully", func(t *testing.T) {
@@ -265,12 +289,12 @@ func TestCourier_CompleteOrder(t *testing.T) {
 }
 
 func TestCourier_CalculateTimeToLocation(t *testing.T) {
-	courier, err := NewCourier("Test Courier", 10, mustCreateLocation(t, 5, 5))
+	courier, err := NewCourier("Test Courier", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 	require.NoError(t, err)
 
 	t.Run("calculate time to nearby location", func(t *testing.T) {
 		targetLocation := mustCreateLocation(t, 7, 5)
-		time, err := courier.CalculateTimeToLocation(targetLocation)
+		time, err := courier.CalculateTimeToLocation(targetLocation, kernel.GridDistance{})
 
 		assert.NoError(t, err)
 		// Distance is 2 (Manhattan), speed is 10, so time should be 0.2
@@ -279,7 +303,7 @@ func TestCourier_CalculateTimeToLocation(t *testing.T) {
 
 	t.Run("calculate time to same location", func(t *testing.T) {
 		targetLocation := mustCreateLocation(t, 5, 5)
-		time, err := courier.CalculateTimeToLocation(targetLocation)
+		time, err := courier.CalculateTimeToLocation(targetLocation, kernel.GridDistance{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, 0.0, time)
@@ -287,21 +311,158 @@ func TestCourier_CalculateTimeToLocation(t *testing.T) {
 
 	t.Run("cannot calculate time to empty location", func(t *testing.T) {
 		emptyLocation := kernel.Location{}
-		time, err := courier.CalculateTimeToLocation(emptyLocation)
+		time, err := courier.CalculateTimeToLocation(emptyLocation, kernel.GridDistance{})
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "location")
 		assert.Equal(t, 0.0, time)
 	})
+
+	t.Run("cannot calculate time without distance", func(t *testing.T) {
+		_, err := courier.CalculateTimeToLocation(mustCreateLocation(t, 7, 5), nil)
+
+		assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+	})
+
+	t.Run("calculate time on map", func(t *testing.T) {
+		origin, err := kernel.NewGeoLocation(55.7558, 37.6173)
+		require.NoError(t, err)
+		distance, err := kernel.NewGeoDistance(origin, 100)
+		require.NoError(t, err)
+
+		// По прямой до (8, 9) 5 клеток, по сетке было бы 7
+		time, err := courier.CalculateTimeToLocation(mustCreateLocation(t, 8, 9), distance)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.5, time, 0.001)
+	})
+
+	t.Run("map estimate never exceeds movement", func(t *testing.T) {
+		origin, err := kernel.NewGeoLocation(55.7558, 37.6173)
+		require.NoError(t, err)
+		distance, err := kernel.NewGeoDistance(origin, 100)
+		require.NoError(t, err)
+
+		tests := []struct {
+			name   string
+			target kernel.Location
+			exact  bool
+		}{
+			{name: "straight street", target: mustCreateLocation(t, 5, 9), exact: true},
+			{name: "diagonal", target: mustCreateLocation(t, 8, 9)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				walker, err := NewCourier("Test Courier", kernel.Pedestrian, 1, mustCreateLocation(t, 5, 5))
+				require.NoError(t, err)
+
+				estimate, err := walker.CalculateTimeToLocation(tt.target, distance)
+				require.NoError(t, err)
+				ticks := ticksToLocation(t, walker, tt.target, mustCreateCityMap(t), kernel.CostMap{})
+
+				// Курьер идет по сетке, а оценка берется по прямой
+				assert.LessOrEqual(t, estimate, float64(ticks)+0.001)
+				if tt.exact {
+					assert.InDelta(t, float64(ticks), estimate, 0.001)
+				}
+			})
+		}
+	})
+
+	t.Run("calculate time along path around obstacle", func(t *testing.T) {
+		// Стена по x = 6 от y = 1 до y = 8, обход через (6, 9)
+		var wall []kernel.Location
+		for y := 1; y <= 8; y++ {
+			wall = append(wall, mustCreateLocation(t, 6, y))
+		}
+		distance, err := kernel.NewPathDistance(mustCreateCityMap(t, wall...), kernel.CostMap{})
+		require.NoError(t, err)
+
+		time, err := courier.CalculateTimeToLocation(mustCreateLocation(t, 7, 5), distance)
+
+		assert.NoError(t, err)
+		// 4 клетки вверх, 2 вправо, 4 вниз
+		assert.Equal(t, 1.0, time)
+	})
+
+	t.Run("calculate time through traffic", func(t *testing.T) {
+		zone, err := kernel.NewTrafficZone("avenue", mustCreateLocation(t, 6, 1), mustCreateLocation(t, 6, 10),
+			map[kernel.Transport]float64{kernel.Car: 3})
+		require.NoError(t, err)
+		costs, err := kernel.NewCostMap([]kernel.TrafficZone{zone})
+		require.NoError(t, err)
+		distance, err := kernel.NewPathDistance(mustCreateCityMap(t), costs)
+		require.NoError(t, err)
+
+		time, err := courier.CalculateTimeToLocation(mustCreateLocation(t, 7, 5), distance)
+
+		assert.NoError(t, err)
+		// Проспект x = 6 не объехать: 3 + 1 при скорости 10
+		assert.InDelta(t, 0.4, time, 1e-9)
+	})
+
+	t.Run("route estimate matches ticks moved", func(t *testing.T) {
+		zone, err := kernel.NewTrafficZone("center", mustCreateLocation(t, 3, 1), mustCreateLocation(t, 6, 10),
+			map[kernel.Transport]float64{kernel.Pedestrian: 3, kernel.Bicycle: 10, kernel.Car: 2.5})
+		require.NoError(t, err)
+		costs, err := kernel.NewCostMap([]kernel.TrafficZone{zone})
+		require.NoError(t, err)
+		cityMap := mustCreateCityMap(t)
+		distance, err := kernel.NewPathDistance(cityMap, costs)
+		require.NoError(t, err)
+		target := mustCreateLocation(t, 9, 4)
+
+		tests := []struct {
+			name      string
+			transport kernel.Transport
+			speed     int
+		}{
+			{name: "pedestrian", transport: kernel.Pedestrian, speed: 1},
+			{name: "bicycle", transport: kernel.Bicycle, speed: 2},
+			{name: "fast bicycle", transport: kernel.Bicycle, speed: 7},
+			{name: "car", transport: kernel.Car, speed: 3},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				courier, err := NewCourier("Test Courier", tt.transport, tt.speed, mustCreateLocation(t, 1, 2))
+				require.NoError(t, err)
+
+				estimate, err := courier.CalculateTimeToLocation(target, distance)
+				require.NoError(t, err)
+				ticks := ticksToLocation(t, courier, target, cityMap, costs)
+
+				assert.Equal(t, math.Ceil(estimate-1e-9), float64(ticks))
+			})
+		}
+	})
+
+	t.Run("estimate counts leftover budget", func(t *testing.T) {
+		zone, err := kernel.NewTrafficZone("jam", mustCreateLocation(t, 1, 1), mustCreateLocation(t, 10, 10),
+			map[kernel.Transport]float64{kernel.Bicycle: 10})
+		require.NoError(t, err)
+		costs, err := kernel.NewCostMap([]kernel.TrafficZone{zone})
+		require.NoError(t, err)
+		distance, err := kernel.NewPathDistance(mustCreateCityMap(t), costs)
+		require.NoError(t, err)
+		cyclist, err := NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 5, 5))
+		require.NoError(t, err)
+		target := mustCreateLocation(t, 5, 6)
+
+		require.NoError(t, cyclist.Move(target, mustCreateCityMap(t), costs))
+		estimate, err := cyclist.CalculateTimeToLocation(target, distance)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4.0, estimate)
+	})
 }
 
 func TestCourier_Move(t *testing.T) {
-	courier, err := NewCourier("Test Courier", 3, mustCreateLocation(t, 5, 5))
+	courier, err := NewCourier("Test Courier", kernel.Car, 3, mustCreateLocation(t, 5, 5))
 	require.NoError(t, err)
 
 	t.Run("move within speed limit", func(t *testing.T) {
 		targetLocation := mustCreateLocation(t, 7, 5)
-		err := courier.Move(targetLocation)
+		err := courier.Move(targetLocation, mustCreateCityMap(t), kernel.CostMap{})
 
 		assert.NoError(t, err)
 		// Should move 2 units in X direction (within speed limit of 3)
@@ -311,10 +472,10 @@ func TestCourier_Move(t *testing.T) {
 
 	t.Run("move beyond speed limit", func(t *testing.T) {
 		// Reset to original position
-		courier, _ = NewCourier("Test Courier", 3, mustCreateLocation(t, 5, 5))
+		courier, _ = NewCourier("Test Courier", kernel.Car, 3, mustCreateLocation(t, 5, 5))
 
 		targetLocation := mustCreateLocation(t, 10, 10)
-		err := courier.Move(targetLocation)
+		err := courier.Move(targetLocation, mustCreateCityMap(t), kernel.CostMap{})
 
 		assert.NoError(t, err)
 		// Should move only 3 units (speed limit) towards target
@@ -325,15 +486,128 @@ func TestCourier_Move(t *testing.T) {
 
 	t.Run("cannot move to empty location", func(t *testing.T) {
 		emptyLocation := kernel.Location{}
-		err := courier.Move(emptyLocation)
+		err := courier.Move(emptyLocation, mustCreateCityMap(t), kernel.CostMap{})
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "location")
 	})
+
+	t.Run("cannot move outside grid", func(t *testing.T) {
+		outside, err := kernel.RestoreLocation(11, 5)
+		require.NoError(t, err)
+
+		err = courier.Move(outside, mustCreateCityMap(t), kernel.CostMap{})
+
+		assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+	})
+
+	t.Run("cannot move without city map", func(t *testing.T) {
+		err := courier.Move(mustCreateLocation(t, 7, 5), kernel.CityMap{}, kernel.CostMap{})
+
+		assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+	})
+
+	t.Run("moves across larger grid", func(t *testing.T) {
+		grid, err := kernel.NewGridOfSize(200, 150)
+		require.NoError(t, err)
+		target, err := kernel.NewLocation(grid, 180, 140)
+		require.NoError(t, err)
+		cityMap, err := kernel.NewCityMap(grid, nil)
+		require.NoError(t, err)
+		courier, _ = NewCourier("Test Courier", kernel.Car, 3, mustCreateLocation(t, 10, 10))
+
+		require.NoError(t, courier.Move(target, cityMap, kernel.CostMap{}))
+
+		assert.Equal(t, 13, courier.Location().X())
+		assert.Equal(t, 10, courier.Location().Y())
+	})
+
+	t.Run("walks around blocked cells", func(t *testing.T) {
+		// Река по x = 6 с бродом только в (6, 1)
+		var river []kernel.Location
+		for y := 2; y <= 10; y++ {
+			river = append(river, mustCreateLocation(t, 6, y))
+		}
+		cityMap := mustCreateCityMap(t, river...)
+		courier, _ = NewCourier("Test Courier", kernel.Car, 3, mustCreateLocation(t, 5, 3))
+		target := mustCreateLocation(t, 7, 3)
+
+		path, err := cityMap.FindPath(courier.Location(), target)
+		require.NoError(t, err)
+		require.Equal(t, 6, path.Length())
+
+		require.NoError(t, courier.Move(target, cityMap, kernel.CostMap{}))
+		assert.Equal(t, mustCreateLocation(t, 6, 1), courier.Location())
+		require.NoError(t, courier.Move(target, cityMap, kernel.CostMap{}))
+		assert.Equal(t, target, courier.Location())
+	})
+
+	t.Run("slows down in traffic", func(t *testing.T) {
+		// Пробка на всех улицах правее x = 1: объехать ее нельзя
+		zone, err := kernel.NewTrafficZone("rush hour", mustCreateLocation(t, 2, 1), mustCreateLocation(t, 10, 10),
+			map[kernel.Transport]float64{kernel.Car: 2})
+		require.NoError(t, err)
+		costs, err := kernel.NewCostMap([]kernel.TrafficZone{zone})
+		require.NoError(t, err)
+		target := mustCreateLocation(t, 10, 1)
+
+		car, _ := NewCourier("Test Courier", kernel.Car, 3, mustCreateLocation(t, 1, 1))
+		require.NoError(t, car.Move(target, mustCreateCityMap(t), costs))
+		assert.Equal(t, mustCreateLocation(t, 2, 1), car.Location())
+
+		walker, _ := NewCourier("Test Courier", kernel.Pedestrian, 1, mustCreateLocation(t, 1, 1))
+		require.NoError(t, walker.Move(target, mustCreateCityMap(t), costs))
+		assert.Equal(t, mustCreateLocation(t, 2, 1), walker.Location())
+		require.NoError(t, walker.Move(target, mustCreateCityMap(t), costs))
+		assert.Equal(t, mustCreateLocation(t, 3, 1), walker.Location())
+	})
+
+	t.Run("crosses a costly cell over several ticks", func(t *testing.T) {
+		zone, err := kernel.NewTrafficZone("jam", mustCreateLocation(t, 1, 1), mustCreateLocation(t, 10, 10),
+			map[kernel.Transport]float64{kernel.Bicycle: 10})
+		require.NoError(t, err)
+		costs, err := kernel.NewCostMap([]kernel.TrafficZone{zone})
+		require.NoError(t, err)
+		courier, _ = NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 5, 5))
+		courier.ClearDomainEvents()
+
+		// Клетка стоит 10, за шаг велосипед копит 2
+		for range 4 {
+			require.NoError(t, courier.Move(mustCreateLocation(t, 5, 9), mustCreateCityMap(t), costs))
+			assert.Equal(t, mustCreateLocation(t, 5, 5), courier.Location())
+		}
+		assert.Equal(t, 8.0, courier.MovementBudget())
+		assert.Empty(t, courier.GetDomainEvents())
+
+		require.NoError(t, courier.Move(mustCreateLocation(t, 5, 9), mustCreateCityMap(t), costs))
+		assert.Equal(t, mustCreateLocation(t, 5, 6), courier.Location())
+		assert.Equal(t, 0.0, courier.MovementBudget())
+	})
+
+	t.Run("leftover budget burns on arrival", func(t *testing.T) {
+		courier, _ = NewCourier("Test Courier", kernel.Car, 3, mustCreateLocation(t, 5, 5))
+
+		require.NoError(t, courier.Move(mustCreateLocation(t, 6, 5), mustCreateCityMap(t), kernel.CostMap{}))
+
+		assert.Equal(t, mustCreateLocation(t, 6, 5), courier.Location())
+		assert.Equal(t, 0.0, courier.MovementBudget())
+	})
+
+	t.Run("stays without passable path", func(t *testing.T) {
+		cityMap := mustCreateCityMap(t, mustCreateLocation(t, 9, 10), mustCreateLocation(t, 10, 9))
+		courier, _ = NewCourier("Test Courier", kernel.Car, 3, mustCreateLocation(t, 5, 5))
+		courier.ClearDomainEvents()
+
+		err := courier.Move(mustCreateLocation(t, 10, 10), cityMap, kernel.CostMap{})
+
+		assert.ErrorIs(t, err, kernel.ErrNoPath)
+		assert.Equal(t, mustCreateLocation(t, 5, 5), courier.Location())
+		assert.Empty(t, courier.GetDomainEvents())
+	})
 }
 
 func TestCourier_StoragePlaceManagement(t *testing.T) {
-	courier, err := NewCourier("Test Courier", 10, mustCreateLocation(t, 5, 5))
+	courier, err := NewCourier("Test Courier", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 	require.NoError(t, err)
 
 	t.Run("multiple storage places work correctly", func(t *testing.T) {
@@ -369,9 +643,183 @@ func TestCourier_StoragePlaceManagement(t *testing.T) {
 	})
 }
 
+func TestCourier_DomainEvents(t *testing.T) {
+	t.Run("new courier raises created and storage place added", func(t *testing.T) {
+		courier, err := NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+
+		events := courier.GetDomainEvents()
+		require.Len(t, events, 2)
+
+		created, ok := events[0].(*CourierCreatedDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, courier.ID(), created.CourierID)
+		assert.Equal(t, "Test Courier", created.Name)
+		assert.Equal(t, 2, created.Speed)
+		assert.Equal(t, EventLocation{X: 1, Y: 1}, created.Location)
+
+		added, ok := events[1].(*StoragePlaceAddedDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, courier.Places()[0].ID(), added.StoragePlaceID)
+		assert.Equal(t, defaultStorageVolume, added.TotalVolume)
+	})
+
+	t.Run("add storage place", func(t *testing.T) {
+		courier, err := NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		courier.ClearDomainEvents()
+
+		require.NoError(t, courier.AddStoragePlace("Багажник", 30))
+
+		events := courier.GetDomainEvents()
+		require.Len(t, events, 1)
+		added, ok := events[0].(*StoragePlaceAddedDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, "Багажник", added.Name)
+		assert.Equal(t, 30, added.TotalVolume)
+	})
+
+	t.Run("take and deliver order", func(t *testing.T) {
+		courier, err := NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		order, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 3, 3), 5)
+		require.NoError(t, err)
+		courier.ClearDomainEvents()
+
+		require.NoError(t, courier.TakeOrder(order))
+		require.NoError(t, courier.CompleteOrder(order))
+
+		events := courier.GetDomainEvents()
+		require.Len(t, events, 2)
+
+		taken, ok := events[0].(*OrderTakenDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, order.ID(), taken.OrderID)
+		assert.Equal(t, courier.Places()[0].ID(), taken.StoragePlaceID)
+
+		delivered, ok := events[1].(*OrderDeliveredDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, order.ID(), delivered.OrderID)
+		assert.Equal(t, courier.ID(), delivered.CourierID)
+	})
+
+	t.Run("take and drop order", func(t *testing.T) {
+		courier, err := NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		order, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 3, 3), 5)
+		require.NoError(t, err)
+		require.NoError(t, courier.TakeOrder(order))
+		courier.ClearDomainEvents()
+
+		require.NoError(t, courier.DropOrder(order))
+
+		assert.Nil(t, courier.Places()[0].OrderID())
+		events := courier.GetDomainEvents()
+		require.Len(t, events, 1)
+		dropped, ok := events[0].(*OrderDroppedDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, order.ID(), dropped.OrderID)
+		assert.Equal(t, courier.ID(), dropped.CourierID)
+		assert.Equal(t, courier.Places()[0].ID(), dropped.StoragePlaceID)
+	})
+
+	t.Run("move raises event with from and to", func(t *testing.T) {
+		courier, err := NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		courier.ClearDomainEvents()
+
+		require.NoError(t, courier.Move(mustCreateLocation(t, 5, 1), mustCreateCityMap(t), kernel.CostMap{}))
+
+		events := courier.GetDomainEvents()
+		require.Len(t, events, 1)
+		moved, ok := events[0].(*CourierMovedDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, EventLocation{X: 1, Y: 1}, moved.From)
+		assert.Equal(t, EventLocation{X: 3, Y: 1}, moved.To)
+	})
+
+	t.Run("move to current location raises nothing", func(t *testing.T) {
+		courier, err := NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		courier.ClearDomainEvents()
+
+		require.NoError(t, courier.Move(mustCreateLocation(t, 1, 1), mustCreateCityMap(t), kernel.CostMap{}))
+
+		assert.Empty(t, courier.GetDomainEvents())
+	})
+
+	t.Run("failed operations raise nothing", func(t *testing.T) {
+		courier, err := NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 1, 1))
+		require.NoError(t, err)
+		order, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 3, 3), 50)
+		require.NoError(t, err)
+		courier.ClearDomainEvents()
+
+		assert.Error(t, courier.TakeOrder(order))
+		assert.Error(t, courier.CompleteOrder(order))
+		assert.ErrorIs(t, courier.DropOrder(order), ErrOrderNotFound)
+		assert.Error(t, courier.DropOrder(nil))
+		assert.Error(t, courier.AddStoragePlace("", 10))
+		assert.Empty(t, courier.GetDomainEvents())
+	})
+}
+
+func TestCourier_DomainEventsRoundTripThroughOutbox(t *testing.T) {
+	registry, err := outbox.NewEventRegistry()
+	require.NoError(t, err)
+	for _, eventType := range []reflect.Type{
+		reflect.TypeOf(CourierCreatedDomainEvent{}),
+		reflect.TypeOf(CourierMovedDomainEvent{}),
+		reflect.TypeOf(StoragePlaceAddedDomainEvent{}),
+		reflect.TypeOf(OrderTakenDomainEvent{}),
+		reflect.TypeOf(OrderDeliveredDomainEvent{}),
+		reflect.TypeOf(OrderDroppedDomainEvent{}),
+	} {
+		require.NoError(t, registry.RegisterDomainEvent(eventType))
+	}
+
+	courier, err := NewCourier("Test Courier", kernel.Bicycle, 2, mustCreateLocation(t, 1, 1))
+	require.NoError(t, err)
+	order, err := order.NewOrder(uuid.New(), mustCreateLocation(t, 3, 3), 5)
+	require.NoError(t, err)
+	require.NoError(t, courier.TakeOrder(order))
+	require.NoError(t, courier.DropOrder(order))
+	require.NoError(t, courier.TakeOrder(order))
+	require.NoError(t, courier.Move(order.Location(), mustCreateCityMap(t), kernel.CostMap{}))
+	require.NoError(t, courier.CompleteOrder(order))
+	require.Len(t, courier.GetDomainEvents(), 7)
+
+	for _, event := range courier.GetDomainEvents() {
+		message, err := outbox.EncodeDomainEvent(event, clock.System())
+		require.NoError(t, err)
+
+		decoded, err := registry.DecodeDomainEvent(&message)
+		require.NoError(t, err)
+		assert.Equal(t, event, decoded)
+	}
+}
+
 // Helper function to create location for testing
+// ticksToLocation двигает курьера к target и возвращает число сделанных шагов
+func ticksToLocation(t *testing.T, courier *Courier, target kernel.Location, cityMap kernel.CityMap, costs kernel.CostMap) int {
+	t.Helper()
+	ticks := 0
+	for courier.Location() != target {
+		require.NoError(t, courier.Move(target, cityMap, costs))
+		ticks++
+		require.Less(t, ticks, 1000, "courier does not reach target")
+	}
+	return ticks
+}
+
 func mustCreateLocation(t *testing.T, x, y int) kernel.Location {
-	location, err := kernel.NewLocation(x, y)
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), x, y)
 	require.NoError(t, err)
 	return location
 }
+
+func mustCreateCityMap(t *testing.T, blocked ...kernel.Location) kernel.CityMap {
+	cityMap, err := kernel.NewCityMap(kernel.DefaultGrid(), blocked)
+	require.NoError(t, err)
+	return cityMap
+}
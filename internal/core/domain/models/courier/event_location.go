@@ -0,0 +1,14 @@
+package courier
+
+import "delivery/internal/core/domain/models/kernel"
+
+// EventLocation — координаты в событиях курьера. kernel.Location не
+// сериализуется в JSON, а события хранятся в outbox именно в нем.
+type EventLocation struct {
+	X int
+	Y int
+}
+
+func newEventLocation(location kernel.Location) EventLocation {
+	return EventLocation{X: location.X(), Y: location.Y()}
+}
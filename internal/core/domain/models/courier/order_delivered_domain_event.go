@@ -0,0 +1,34 @@
+package courier
+
+import (
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &OrderDeliveredDomainEvent{}
+
+// OrderDeliveredDomainEvent — курьер доставил заказ и освободил место хранения
+type OrderDeliveredDomainEvent struct {
+	ID             uuid.UUID
+	CourierID      uuid.UUID
+	OrderID        uuid.UUID
+	StoragePlaceID uuid.UUID
+}
+
+func NewOrderDeliveredDomainEvent(aggregate *Courier, orderID uuid.UUID, storagePlace *StoragePlace) *OrderDeliveredDomainEvent {
+	return &OrderDeliveredDomainEvent{
+		ID:             uuid.New(),
+		CourierID:      aggregate.ID(),
+		OrderID:        orderID,
+		StoragePlaceID: storagePlace.ID(),
+	}
+}
+
+func (e *OrderDeliveredDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *OrderDeliveredDomainEvent) GetName() string {
+	return "OrderDeliveredDomainEvent"
+}
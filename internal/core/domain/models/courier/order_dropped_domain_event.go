@@ -0,0 +1,35 @@
+package courier
+
+import (
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &OrderDroppedDomainEvent{}
+
+// OrderDroppedDomainEvent — курьер отказался от недостижимого заказа и
+// освободил место хранения
+type OrderDroppedDomainEvent struct {
+	ID             uuid.UUID
+	CourierID      uuid.UUID
+	OrderID        uuid.UUID
+	StoragePlaceID uuid.UUID
+}
+
+func NewOrderDroppedDomainEvent(aggregate *Courier, orderID uuid.UUID, storagePlace *StoragePlace) *OrderDroppedDomainEvent {
+	return &OrderDroppedDomainEvent{
+		ID:             uuid.New(),
+		CourierID:      aggregate.ID(),
+		OrderID:        orderID,
+		StoragePlaceID: storagePlace.ID(),
+	}
+}
+
+func (e *OrderDroppedDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *OrderDroppedDomainEvent) GetName() string {
+	return "OrderDroppedDomainEvent"
+}
@@ -0,0 +1,34 @@
+package courier
+
+import (
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &OrderTakenDomainEvent{}
+
+// OrderTakenDomainEvent — курьер положил заказ в место хранения
+type OrderTakenDomainEvent struct {
+	ID             uuid.UUID
+	CourierID      uuid.UUID
+	OrderID        uuid.UUID
+	StoragePlaceID uuid.UUID
+}
+
+func NewOrderTakenDomainEvent(aggregate *Courier, orderID uuid.UUID, storagePlace *StoragePlace) *OrderTakenDomainEvent {
+	return &OrderTakenDomainEvent{
+		ID:             uuid.New(),
+		CourierID:      aggregate.ID(),
+		OrderID:        orderID,
+		StoragePlaceID: storagePlace.ID(),
+	}
+}
+
+func (e *OrderTakenDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *OrderTakenDomainEvent) GetName() string {
+	return "OrderTakenDomainEvent"
+}
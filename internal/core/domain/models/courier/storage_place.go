@@ -37,6 +37,15 @@ func NewStoragePlace(name string, totalVolume int) (*StoragePlace, error) {
 	}, nil
 }
 
+func RestoreStoragePlace(id uuid.UUID, name string, totalVolume int, orderID *uuid.UUID) *StoragePlace {
+	return &StoragePlace{
+		id:          id,
+		name:        name,
+		totalVolume: totalVolume,
+		orderID:     orderID,
+	}
+}
+
 func (s *StoragePlace) Equals(other *StoragePlace) bool {
 	if other == nil {
 		return false
@@ -0,0 +1,36 @@
+package courier
+
+import (
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &StoragePlaceAddedDomainEvent{}
+
+// StoragePlaceAddedDomainEvent — у курьера появилось место хранения
+type StoragePlaceAddedDomainEvent struct {
+	ID             uuid.UUID
+	CourierID      uuid.UUID
+	StoragePlaceID uuid.UUID
+	Name           string
+	TotalVolume    int
+}
+
+func NewStoragePlaceAddedDomainEvent(aggregate *Courier, storagePlace *StoragePlace) *StoragePlaceAddedDomainEvent {
+	return &StoragePlaceAddedDomainEvent{
+		ID:             uuid.New(),
+		CourierID:      aggregate.ID(),
+		StoragePlaceID: storagePlace.ID(),
+		Name:           storagePlace.Name(),
+		TotalVolume:    storagePlace.TotalVolume(),
+	}
+}
+
+func (e *StoragePlaceAddedDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *StoragePlaceAddedDomainEvent) GetName() string {
+	return "StoragePlaceAddedDomainEvent"
+}
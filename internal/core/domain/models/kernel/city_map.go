@@ -0,0 +1,89 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/random"
+	"errors"
+)
+
+var (
+	ErrNoPath             = errors.New("no passable path")
+	ErrNoPassableLocation = errors.New("no passable location")
+)
+
+// CityMap — сетка города с непроходимыми клетками: реками, перекрытыми
+// улицами. Карта неизменяема после создания.
+type CityMap struct {
+	grid    Grid
+	blocked map[Location]struct{}
+}
+
+func NewCityMap(grid Grid, blocked []Location) (CityMap, error) {
+	if grid.IsEmpty() {
+		return CityMap{}, errs.NewValueIsRequiredError("grid")
+	}
+
+	cityMap := CityMap{
+		grid:    grid,
+		blocked: make(map[Location]struct{}, len(blocked)),
+	}
+	for _, location := range blocked {
+		if !grid.Contains(location) {
+			return CityMap{}, errs.NewValueIsInvalidError("blocked")
+		}
+		cityMap.blocked[location] = struct{}{}
+	}
+	return cityMap, nil
+}
+
+func (m CityMap) Grid() Grid {
+	return m.grid
+}
+
+func (m CityMap) IsEmpty() bool {
+	return m.grid.IsEmpty()
+}
+
+// IsPassable сообщает, можно ли пройти через клетку
+func (m CityMap) IsPassable(location Location) bool {
+	if !m.grid.Contains(location) {
+		return false
+	}
+	_, blocked := m.blocked[location]
+	return !blocked
+}
+
+// NewRandomLocation выбирает случайную проходимую клетку. Пока выпавшая клетка
+// проходима, результат совпадает с kernel.NewRandomLocation.
+func (m CityMap) NewRandomLocation(source random.Source) (Location, error) {
+	if m.IsEmpty() {
+		return Location{}, ErrInvalidGrid
+	}
+
+	location, err := NewRandomLocation(m.grid, source)
+	if err != nil {
+		return Location{}, err
+	}
+	if m.IsPassable(location) {
+		return location, nil
+	}
+
+	passable := m.grid.Width()*m.grid.Height() - len(m.blocked)
+	if passable == 0 {
+		return Location{}, ErrNoPassableLocation
+	}
+	skip := source.Intn(passable)
+	for y := m.grid.minY; y <= m.grid.maxY; y++ {
+		for x := m.grid.minX; x <= m.grid.maxX; x++ {
+			location := Location{x, y, true}
+			if !m.IsPassable(location) {
+				continue
+			}
+			if skip == 0 {
+				return location, nil
+			}
+			skip--
+		}
+	}
+	return Location{}, ErrNoPassableLocation
+}
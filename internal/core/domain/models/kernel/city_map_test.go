@@ -0,0 +1,65 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/random"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustCreateCityMap(t *testing.T, grid Grid, blocked ...Location) CityMap {
+	cityMap, err := NewCityMap(grid, blocked)
+	require.NoError(t, err)
+	return cityMap
+}
+
+func TestNewCityMap(t *testing.T) {
+	_, err := NewCityMap(Grid{}, nil)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = NewCityMap(DefaultGrid(), []Location{{11, 1, true}})
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+
+	cityMap := mustCreateCityMap(t, DefaultGrid(), Location{3, 3, true})
+	assert.False(t, cityMap.IsEmpty())
+	assert.True(t, cityMap.Grid().Equals(DefaultGrid()))
+	assert.False(t, cityMap.IsPassable(Location{3, 3, true}))
+	assert.True(t, cityMap.IsPassable(Location{3, 4, true}))
+	assert.False(t, cityMap.IsPassable(Location{11, 4, true}))
+}
+
+func TestCityMap_NewRandomLocation(t *testing.T) {
+	t.Run("keeps passable random cell", func(t *testing.T) {
+		cityMap := mustCreateCityMap(t, DefaultGrid(), Location{3, 3, true})
+
+		location, err := cityMap.NewRandomLocation(random.NewFake(4, 8))
+
+		require.NoError(t, err)
+		assert.Equal(t, Location{5, 9, true}, location)
+	})
+
+	t.Run("picks another cell when blocked", func(t *testing.T) {
+		grid, err := NewGridOfSize(2, 2)
+		require.NoError(t, err)
+		cityMap := mustCreateCityMap(t, grid, Location{1, 1, true}, Location{2, 1, true})
+
+		for range 100 {
+			location, err := cityMap.NewRandomLocation(random.System())
+
+			require.NoError(t, err)
+			assert.True(t, cityMap.IsPassable(location))
+		}
+	})
+
+	t.Run("fails when every cell is blocked", func(t *testing.T) {
+		grid, err := NewGridOfSize(1, 2)
+		require.NoError(t, err)
+		cityMap := mustCreateCityMap(t, grid, Location{1, 1, true}, Location{1, 2, true})
+
+		_, err = cityMap.NewRandomLocation(random.NewSeeded(1))
+
+		assert.ErrorIs(t, err, ErrNoPassableLocation)
+	})
+}
@@ -0,0 +1,140 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Стоимость шага по клетке без пробок
+const baseStepCost = 1.0
+
+// TrafficZone — прямоугольный район, где шаг обходится дороже: пробки в час
+// пик, стройка, подъем. Множитель задается для каждого вида транспорта,
+// не указанный транспорт район не замечает.
+type TrafficZone struct {
+	name        string
+	from        Location
+	to          Location
+	multipliers map[Transport]float64
+}
+
+// NewTrafficZone создает район с углами from и to. Множители не меньше 1:
+// район может только замедлять, иначе поиск маршрута перестал бы находить
+// кратчайший путь.
+func NewTrafficZone(name string, from Location, to Location, multipliers map[Transport]float64) (TrafficZone, error) {
+	if name == "" {
+		return TrafficZone{}, errs.NewValueIsRequiredError("name")
+	}
+	if from.IsEmpty() {
+		return TrafficZone{}, errs.NewValueIsRequiredError("from")
+	}
+	if to.IsEmpty() {
+		return TrafficZone{}, errs.NewValueIsRequiredError("to")
+	}
+	if len(multipliers) == 0 {
+		return TrafficZone{}, errs.NewValueIsRequiredError("multipliers")
+	}
+	for transport, multiplier := range multipliers {
+		if !slices.Contains(Transports, transport) {
+			return TrafficZone{}, errs.NewValueIsInvalidError("transport")
+		}
+		if math.IsNaN(multiplier) || math.IsInf(multiplier, 0) || multiplier < baseStepCost {
+			return TrafficZone{}, errs.NewValueIsOutOfRangeError("multiplier", multiplier, baseStepCost, math.MaxFloat64)
+		}
+	}
+
+	return TrafficZone{
+		name:        name,
+		from:        Location{min(from.x, to.x), min(from.y, to.y), true},
+		to:          Location{max(from.x, to.x), max(from.y, to.y), true},
+		multipliers: maps.Clone(multipliers),
+	}, nil
+}
+
+func (z TrafficZone) Name() string {
+	return z.name
+}
+
+// From — левый нижний угол района
+func (z TrafficZone) From() Location {
+	return z.from
+}
+
+// To — правый верхний угол района
+func (z TrafficZone) To() Location {
+	return z.to
+}
+
+func (z TrafficZone) Multipliers() map[Transport]float64 {
+	return maps.Clone(z.multipliers)
+}
+
+func (z TrafficZone) Contains(location Location) bool {
+	return location.x >= z.from.x && location.x <= z.to.x && location.y >= z.from.y && location.y <= z.to.y
+}
+
+// CostMapSource отдает действующую карту стоимости. Карту меняют во время
+// работы сервиса, поэтому потребители спрашивают ее на каждом расчете.
+type CostMapSource interface {
+	CostMap() CostMap
+}
+
+var _ CostMapSource = CostMap{}
+
+// CostMap — стоимость шага в каждую клетку для каждого вида транспорта.
+// Пустая карта — город без пробок, где любой шаг стоит 1.
+type CostMap struct {
+	zones []TrafficZone
+}
+
+// NewCostMap собирает карту из районов. Имена районов уникальны.
+func NewCostMap(zones []TrafficZone) (CostMap, error) {
+	names := make(map[string]struct{}, len(zones))
+	for _, zone := range zones {
+		if zone.name == "" {
+			return CostMap{}, errs.NewValueIsRequiredError("zone")
+		}
+		if _, ok := names[zone.name]; ok {
+			return CostMap{}, errs.NewValueIsInvalidError("zones")
+		}
+		names[zone.name] = struct{}{}
+	}
+
+	return CostMap{zones: slices.Clone(zones)}, nil
+}
+
+// CostMap позволяет передать неизменную карту туда, где ждут CostMapSource
+func (m CostMap) CostMap() CostMap {
+	return m
+}
+
+func (m CostMap) Zones() []TrafficZone {
+	return slices.Clone(m.zones)
+}
+
+func (m CostMap) IsEmpty() bool {
+	return len(m.zones) == 0
+}
+
+// StepCost — стоимость шага в клетку location. Пересекающиеся районы не
+// складываются: действует самый медленный.
+func (m CostMap) StepCost(location Location, transport Transport) float64 {
+	cost := baseStepCost
+	for _, zone := range m.zones {
+		if multiplier, ok := zone.multipliers[transport]; ok && zone.Contains(location) {
+			cost = max(cost, baseStepCost*multiplier)
+		}
+	}
+	return cost
+}
+
+// PathCost — стоимость прохода по маршруту
+func (m CostMap) PathCost(path Path, transport Transport) float64 {
+	cost := 0.0
+	for _, location := range path {
+		cost += m.StepCost(location, transport)
+	}
+	return cost
+}
@@ -0,0 +1,152 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustCreateTrafficZone(t *testing.T, name string, from, to Location, multipliers map[Transport]float64) TrafficZone {
+	t.Helper()
+	zone, err := NewTrafficZone(name, from, to, multipliers)
+	require.NoError(t, err)
+	return zone
+}
+
+func mustCreateCostMap(t *testing.T, zones ...TrafficZone) CostMap {
+	t.Helper()
+	costMap, err := NewCostMap(zones)
+	require.NoError(t, err)
+	return costMap
+}
+
+func TestNewTrafficZone(t *testing.T) {
+	t.Run("normalizes corners", func(t *testing.T) {
+		zone := mustCreateTrafficZone(t, "center", Location{8, 2, true}, Location{3, 7, true}, map[Transport]float64{Car: 3})
+
+		assert.Equal(t, Location{3, 2, true}, zone.From())
+		assert.Equal(t, Location{8, 7, true}, zone.To())
+		assert.True(t, zone.Contains(Location{5, 5, true}))
+		assert.False(t, zone.Contains(Location{9, 5, true}))
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		from, to := Location{1, 1, true}, Location{2, 2, true}
+
+		_, err := NewTrafficZone("", from, to, map[Transport]float64{Car: 2})
+		assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+		_, err = NewTrafficZone("zone", Location{}, to, map[Transport]float64{Car: 2})
+		assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+		_, err = NewTrafficZone("zone", from, to, nil)
+		assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+		_, err = NewTrafficZone("zone", from, to, map[Transport]float64{Transport(42): 2})
+		assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+		_, err = NewTrafficZone("zone", from, to, map[Transport]float64{Car: 0.5})
+		assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+		_, err = NewTrafficZone("zone", from, to, map[Transport]float64{Car: math.NaN()})
+		assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+	})
+
+	t.Run("multipliers are copied", func(t *testing.T) {
+		multipliers := map[Transport]float64{Car: 2}
+		zone := mustCreateTrafficZone(t, "zone", Location{1, 1, true}, Location{2, 2, true}, multipliers)
+
+		multipliers[Car] = 10
+		zone.Multipliers()[Car] = 10
+
+		assert.Equal(t, map[Transport]float64{Car: 2}, zone.Multipliers())
+	})
+}
+
+func TestNewCostMap(t *testing.T) {
+	zone := mustCreateTrafficZone(t, "zone", Location{1, 1, true}, Location{2, 2, true}, map[Transport]float64{Car: 2})
+
+	_, err := NewCostMap([]TrafficZone{zone, zone})
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+	_, err = NewCostMap([]TrafficZone{{}})
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	costMap := mustCreateCostMap(t, zone)
+	assert.False(t, costMap.IsEmpty())
+	assert.Equal(t, []TrafficZone{zone}, costMap.Zones())
+	assert.True(t, CostMap{}.IsEmpty())
+}
+
+func TestCostMap_StepCost(t *testing.T) {
+	costMap := mustCreateCostMap(t,
+		mustCreateTrafficZone(t, "center", Location{3, 3, true}, Location{6, 6, true}, map[Transport]float64{Car: 3, Bicycle: 1.5}),
+		mustCreateTrafficZone(t, "bridge", Location{5, 5, true}, Location{8, 5, true}, map[Transport]float64{Car: 4}),
+	)
+
+	assert.Equal(t, 1.0, costMap.StepCost(Location{1, 1, true}, Car))
+	assert.Equal(t, 3.0, costMap.StepCost(Location{4, 4, true}, Car))
+	assert.Equal(t, 1.5, costMap.StepCost(Location{4, 4, true}, Bicycle))
+	assert.Equal(t, 1.0, costMap.StepCost(Location{4, 4, true}, Pedestrian))
+	// Пересечение районов: действует самый медленный
+	assert.Equal(t, 4.0, costMap.StepCost(Location{5, 5, true}, Car))
+	assert.Equal(t, 4.0, costMap.StepCost(Location{8, 5, true}, Car))
+
+	path := Path{{2, 3, true}, {3, 3, true}, {4, 3, true}}
+	assert.Equal(t, 7.0, costMap.PathCost(path, Car))
+	assert.Equal(t, 3.0, costMap.PathCost(path, Pedestrian))
+}
+
+func TestCityMap_FindRoute(t *testing.T) {
+	cityMap := mustCreateCityMap(t, DefaultGrid())
+	// Час пик в центре: машины едут впятеро медленнее, пешеходы не замечают
+	costs := mustCreateCostMap(t,
+		mustCreateTrafficZone(t, "rush hour", Location{3, 3, true}, Location{8, 7, true}, map[Transport]float64{Car: 5}),
+	)
+	from, to := Location{1, 5, true}, Location{10, 5, true}
+
+	t.Run("car drives around rush hour", func(t *testing.T) {
+		path, err := cityMap.FindRoute(from, to, costs, Car)
+
+		require.NoError(t, err)
+		assert.Equal(t, to, path[path.Length()-1])
+		assertConnected(t, cityMap, from, path)
+		for _, location := range path {
+			assert.Equal(t, 1.0, costs.StepCost(location, Car))
+		}
+		assert.Equal(t, 15.0, costs.PathCost(path, Car))
+	})
+
+	t.Run("pedestrian walks through", func(t *testing.T) {
+		path, err := cityMap.FindRoute(from, to, costs, Pedestrian)
+
+		require.NoError(t, err)
+		assert.Equal(t, 9, path.Length())
+		assertConnected(t, cityMap, from, path)
+	})
+
+	t.Run("pays for zone that cannot be avoided", func(t *testing.T) {
+		light := mustCreateCostMap(t,
+			mustCreateTrafficZone(t, "slow", Location{3, 1, true}, Location{8, 10, true}, map[Transport]float64{Car: 1.5}),
+		)
+
+		path, err := cityMap.FindRoute(from, to, light, Car)
+
+		require.NoError(t, err)
+		assert.Equal(t, 9, path.Length())
+		assert.Equal(t, 12.0, light.PathCost(path, Car))
+	})
+}
+
+func TestPathDistance_Between_WithTraffic(t *testing.T) {
+	costs := mustCreateCostMap(t,
+		mustCreateTrafficZone(t, "column", Location{3, 1, true}, Location{3, 10, true}, map[Transport]float64{Car: 4}),
+	)
+	distance, err := NewPathDistance(mustCreateCityMap(t, DefaultGrid()), costs)
+	require.NoError(t, err)
+
+	car, err := distance.Between(Location{1, 1, true}, Location{5, 1, true}, Car)
+	require.NoError(t, err)
+	assert.Equal(t, 7.0, car)
+
+	pedestrian, err := distance.Between(Location{1, 1, true}, Location{5, 1, true}, Pedestrian)
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, pedestrian)
+}
@@ -0,0 +1,123 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"math"
+)
+
+// Distance измеряет расстояние между точками города в клетках сетки: курьер
+// со скоростью speed проходит speed клеток за один шаг. Мера может зависеть
+// от транспорта курьера. От выбранной меры зависят время до заказа и выбор
+// курьера.
+type Distance interface {
+	Between(from Location, to Location, transport Transport) (float64, error)
+}
+
+var (
+	_ Distance = GridDistance{}
+	_ Distance = GeoDistance{}
+	_ Distance = PathDistance{}
+)
+
+// GridDistance — манхэттенское расстояние по клеткам сетки, режим по умолчанию
+type GridDistance struct{}
+
+func (GridDistance) Between(from Location, to Location, _ Transport) (float64, error) {
+	if from.IsEmpty() {
+		return 0, ErrInvalidLocation
+	}
+
+	distance, err := from.DistanceTo(to)
+	if err != nil {
+		return 0, err
+	}
+	return float64(distance), nil
+}
+
+// PathDistance — стоимость самого дешевого проходимого маршрута по карте
+// города с учетом пробок для транспорта курьера. Без препятствий и пробок
+// совпадает с GridDistance. Если маршрута нет, возвращает ErrNoPath.
+type PathDistance struct {
+	cityMap CityMap
+	costs   CostMapSource
+}
+
+func NewPathDistance(cityMap CityMap, costs CostMapSource) (PathDistance, error) {
+	if cityMap.IsEmpty() {
+		return PathDistance{}, errs.NewValueIsRequiredError("cityMap")
+	}
+	if costs == nil {
+		return PathDistance{}, errs.NewValueIsRequiredError("costs")
+	}
+	return PathDistance{cityMap: cityMap, costs: costs}, nil
+}
+
+func (d PathDistance) Between(from Location, to Location, transport Transport) (float64, error) {
+	costs := d.costs.CostMap()
+	path, err := d.cityMap.FindRoute(from, to, costs, transport)
+	if err != nil {
+		return 0, err
+	}
+	return costs.PathCost(path, transport), nil
+}
+
+// GeoDistance — оценка по прямой для сетки, приближенно спроецированной на
+// карту: клетка (1, 1) находится в точке origin, ось X направлена на восток,
+// ось Y — на север, сторона клетки равна cellSize метров. Расстояние
+// считается по дуге большого круга между центрами клеток и выражается в
+// клетках. Точки остаются клетками сетки, и курьер движется по ней, поэтому
+// оценка не превышает числа клеток, которые он пройдет на самом деле, и
+// совпадает с ним только на прямой улице без пробок.
+type GeoDistance struct {
+	origin   GeoLocation
+	cellSize float64
+}
+
+func NewGeoDistance(origin GeoLocation, cellSize float64) (GeoDistance, error) {
+	if origin.IsEmpty() {
+		return GeoDistance{}, errs.NewValueIsRequiredError("origin")
+	}
+	// На полюсе долгота вырождается, и сетку нельзя сориентировать по сторонам света
+	if math.Abs(origin.latitude) == 90 {
+		return GeoDistance{}, errs.NewValueIsOutOfRangeError("origin", origin.latitude, -90, 90)
+	}
+	if math.IsNaN(cellSize) || cellSize <= 0 {
+		return GeoDistance{}, errs.NewValueIsOutOfRangeError("cellSize", cellSize, 0, math.MaxFloat64)
+	}
+
+	return GeoDistance{
+		origin:   origin,
+		cellSize: cellSize,
+	}, nil
+}
+
+func (d GeoDistance) Between(from Location, to Location, _ Transport) (float64, error) {
+	fromGeo, err := d.ToGeoLocation(from)
+	if err != nil {
+		return 0, err
+	}
+	toGeo, err := d.ToGeoLocation(to)
+	if err != nil {
+		return 0, err
+	}
+
+	meters, err := fromGeo.DistanceTo(toGeo)
+	if err != nil {
+		return 0, err
+	}
+	return meters / d.cellSize, nil
+}
+
+// ToGeoLocation возвращает приближенные координаты центра клетки на карте
+func (d GeoDistance) ToGeoLocation(location Location) (GeoLocation, error) {
+	if location.IsEmpty() {
+		return GeoLocation{}, ErrInvalidLocation
+	}
+
+	metersPerDegree := earthRadiusMeters * math.Pi / 180
+	latitude := d.origin.latitude + float64(location.y-minCoordinate)*d.cellSize/metersPerDegree
+	longitude := d.origin.longitude +
+		float64(location.x-minCoordinate)*d.cellSize/(metersPerDegree*math.Cos(degreesToRadians(d.origin.latitude)))
+
+	return NewGeoLocation(latitude, longitude)
+}
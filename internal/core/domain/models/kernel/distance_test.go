@@ -0,0 +1,88 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGridDistance(t *testing.T) {
+	distance, err := GridDistance{}.Between(Location{1, 1, true}, Location{5, 5, true}, Car)
+	require.NoError(t, err)
+	assert.Equal(t, 8.0, distance)
+
+	_, err = GridDistance{}.Between(Location{1, 1, true}, Location{}, Car)
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+
+	_, err = GridDistance{}.Between(Location{}, Location{1, 1, true}, Car)
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+}
+
+func TestNewGeoDistance(t *testing.T) {
+	origin, err := NewGeoLocation(55.7558, 37.6173)
+	require.NoError(t, err)
+	pole, err := NewGeoLocation(90, 0)
+	require.NoError(t, err)
+
+	_, err = NewGeoDistance(GeoLocation{}, 100)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = NewGeoDistance(origin, 0)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+
+	_, err = NewGeoDistance(pole, 100)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+}
+
+func TestGeoDistance(t *testing.T) {
+	origin, err := NewGeoLocation(55.7558, 37.6173)
+	require.NoError(t, err)
+	distance, err := NewGeoDistance(origin, 100)
+	require.NoError(t, err)
+
+	t.Run("cell (1, 1) is origin", func(t *testing.T) {
+		location, err := distance.ToGeoLocation(Location{1, 1, true})
+
+		require.NoError(t, err)
+		assert.True(t, origin.Equals(location))
+	})
+
+	t.Run("straight lines match grid", func(t *testing.T) {
+		east, err := distance.Between(Location{1, 1, true}, Location{11, 1, true}, Car)
+		require.NoError(t, err)
+		assert.InDelta(t, 10, east, 0.01)
+
+		north, err := distance.Between(Location{1, 1, true}, Location{1, 11, true}, Car)
+		require.NoError(t, err)
+		assert.InDelta(t, 10, north, 0.01)
+	})
+
+	t.Run("diagonal is shorter than grid", func(t *testing.T) {
+		cells, err := distance.Between(Location{1, 1, true}, Location{4, 5, true}, Car)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 5, cells, 0.01)
+	})
+
+	t.Run("cell size sets meters on map", func(t *testing.T) {
+		largeCells, err := NewGeoDistance(origin, 200)
+		require.NoError(t, err)
+
+		from, err := largeCells.ToGeoLocation(Location{1, 1, true})
+		require.NoError(t, err)
+		to, err := largeCells.ToGeoLocation(Location{11, 1, true})
+		require.NoError(t, err)
+		meters, err := from.DistanceTo(to)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 2000, meters, 1)
+	})
+
+	t.Run("empty location", func(t *testing.T) {
+		_, err := distance.Between(Location{1, 1, true}, Location{}, Car)
+
+		assert.ErrorIs(t, err, ErrInvalidLocation)
+	})
+}
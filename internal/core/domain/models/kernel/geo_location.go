@@ -0,0 +1,69 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"math"
+)
+
+// Средний радиус Земли в метрах
+const earthRadiusMeters = 6371008.8
+
+// GeoLocation — точка на карте в градусах широты и долготы. Заказы и курьеры
+// хранят клетки сетки, а не GeoLocation: координаты нужны только GeoDistance,
+// чтобы оценить расстояние между клетками.
+type GeoLocation struct {
+	latitude  float64
+	longitude float64
+
+	isSet bool
+}
+
+func NewGeoLocation(latitude float64, longitude float64) (GeoLocation, error) {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return GeoLocation{}, errs.NewValueIsOutOfRangeError("latitude", latitude, -90, 90)
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return GeoLocation{}, errs.NewValueIsOutOfRangeError("longitude", longitude, -180, 180)
+	}
+
+	return GeoLocation{
+		latitude:  latitude,
+		longitude: longitude,
+		isSet:     true,
+	}, nil
+}
+
+func (l GeoLocation) Latitude() float64 {
+	return l.latitude
+}
+
+func (l GeoLocation) Longitude() float64 {
+	return l.longitude
+}
+
+func (l GeoLocation) Equals(other GeoLocation) bool {
+	return l == other
+}
+
+func (l GeoLocation) IsEmpty() bool {
+	return !l.isSet
+}
+
+// DistanceTo возвращает расстояние по дуге большого круга в метрах (формула гаверсинусов)
+func (l GeoLocation) DistanceTo(target GeoLocation) (float64, error) {
+	if target.IsEmpty() {
+		return 0, ErrInvalidLocation
+	}
+
+	lat1 := degreesToRadians(l.latitude)
+	lat2 := degreesToRadians(target.latitude)
+	dLat := lat2 - lat1
+	dLon := degreesToRadians(target.longitude - l.longitude)
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(min(h, 1))), nil
+}
+
+func degreesToRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
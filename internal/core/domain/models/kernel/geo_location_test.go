@@ -0,0 +1,55 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGeoLocation(t *testing.T) {
+	location, err := NewGeoLocation(55.7558, 37.6173)
+
+	require.NoError(t, err)
+	assert.False(t, location.IsEmpty())
+	assert.Equal(t, 55.7558, location.Latitude())
+	assert.Equal(t, 37.6173, location.Longitude())
+}
+
+func TestNewGeoLocationOutOfRange(t *testing.T) {
+	for _, coordinates := range [][2]float64{{90.1, 0}, {-91, 0}, {0, 180.5}, {0, -181}, {math.NaN(), 0}} {
+		_, err := NewGeoLocation(coordinates[0], coordinates[1])
+
+		assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+	}
+}
+
+func TestGeoLocationDistanceTo(t *testing.T) {
+	moscow, err := NewGeoLocation(55.7558, 37.6173)
+	require.NoError(t, err)
+	saintPetersburg, err := NewGeoLocation(59.9386, 30.3141)
+	require.NoError(t, err)
+
+	distance, err := moscow.DistanceTo(saintPetersburg)
+	require.NoError(t, err)
+	assert.InDelta(t, 634_000, distance, 2_000)
+
+	back, err := saintPetersburg.DistanceTo(moscow)
+	require.NoError(t, err)
+	assert.InDelta(t, distance, back, 1e-6)
+
+	same, err := moscow.DistanceTo(moscow)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, same)
+}
+
+func TestGeoLocationDistanceToEmpty(t *testing.T) {
+	moscow, err := NewGeoLocation(55.7558, 37.6173)
+	require.NoError(t, err)
+
+	_, err = moscow.DistanceTo(GeoLocation{})
+
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+}
@@ -0,0 +1,113 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"math"
+)
+
+// Grid — прямоугольная сетка города, в которой лежат все координаты. Размер
+// задается при развертывании: у каждого города своя карта.
+type Grid struct {
+	minX int
+	minY int
+	maxX int
+	maxY int
+
+	isSet bool
+}
+
+// Координаты любой сетки начинаются не меньше чем с 1
+const minCoordinate = 1
+
+// Границы демонстрационного города 10×10
+const (
+	defaultMinX = 1
+	defaultMinY = 1
+	defaultMaxX = 10
+	defaultMaxY = 10
+)
+
+func NewGrid(minX int, minY int, maxX int, maxY int) (Grid, error) {
+	if minX < minCoordinate {
+		return Grid{}, errs.NewValueIsOutOfRangeError("minX", minX, minCoordinate, math.MaxInt)
+	}
+	if minY < minCoordinate {
+		return Grid{}, errs.NewValueIsOutOfRangeError("minY", minY, minCoordinate, math.MaxInt)
+	}
+	if maxX < minX {
+		return Grid{}, errs.NewValueIsOutOfRangeError("maxX", maxX, minX, math.MaxInt)
+	}
+	if maxY < minY {
+		return Grid{}, errs.NewValueIsOutOfRangeError("maxY", maxY, minY, math.MaxInt)
+	}
+
+	return Grid{
+		minX:  minX,
+		minY:  minY,
+		maxX:  maxX,
+		maxY:  maxY,
+		isSet: true,
+	}, nil
+}
+
+// NewGridOfSize создает сетку width×height с координатами от 1
+func NewGridOfSize(width int, height int) (Grid, error) {
+	if width <= 0 {
+		return Grid{}, errs.NewValueIsOutOfRangeError("width", width, 1, math.MaxInt)
+	}
+	if height <= 0 {
+		return Grid{}, errs.NewValueIsOutOfRangeError("height", height, 1, math.MaxInt)
+	}
+	return NewGrid(minCoordinate, minCoordinate, width, height)
+}
+
+// DefaultGrid — сетка 10×10, в которой сервис работал изначально
+func DefaultGrid() Grid {
+	return Grid{
+		minX:  defaultMinX,
+		minY:  defaultMinY,
+		maxX:  defaultMaxX,
+		maxY:  defaultMaxY,
+		isSet: true,
+	}
+}
+
+func (g Grid) MinX() int {
+	return g.minX
+}
+
+func (g Grid) MinY() int {
+	return g.minY
+}
+
+func (g Grid) MaxX() int {
+	return g.maxX
+}
+
+func (g Grid) MaxY() int {
+	return g.maxY
+}
+
+func (g Grid) Width() int {
+	return g.maxX - g.minX + 1
+}
+
+func (g Grid) Height() int {
+	return g.maxY - g.minY + 1
+}
+
+func (g Grid) IsEmpty() bool {
+	return !g.isSet
+}
+
+func (g Grid) Contains(location Location) bool {
+	return !location.IsEmpty() && g.contains(location.x, location.y)
+}
+
+func (g Grid) Equals(other Grid) bool {
+	return g == other
+}
+
+func (g Grid) contains(x int, y int) bool {
+	return x >= g.minX && x <= g.maxX && y >= g.minY && y <= g.maxY
+}
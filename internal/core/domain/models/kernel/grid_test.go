@@ -0,0 +1,56 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultGrid(t *testing.T) {
+	grid := DefaultGrid()
+
+	assert.False(t, grid.IsEmpty())
+	assert.Equal(t, 1, grid.MinX())
+	assert.Equal(t, 1, grid.MinY())
+	assert.Equal(t, 10, grid.MaxX())
+	assert.Equal(t, 10, grid.MaxY())
+}
+
+func TestNewGridOfSize(t *testing.T) {
+	grid, err := NewGridOfSize(200, 150)
+
+	require.NoError(t, err)
+	assert.Equal(t, 200, grid.Width())
+	assert.Equal(t, 150, grid.Height())
+	assert.Equal(t, 200, grid.MaxX())
+	assert.Equal(t, 150, grid.MaxY())
+}
+
+func TestNewGridOfSizeInvalid(t *testing.T) {
+	_, err := NewGridOfSize(0, 10)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+
+	_, err = NewGridOfSize(10, -1)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+}
+
+func TestNewGridInvalidBounds(t *testing.T) {
+	_, err := NewGrid(5, 1, 4, 10)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+
+	_, err = NewGrid(0, 1, 4, 10)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+}
+
+func TestGridContains(t *testing.T) {
+	grid, err := NewGrid(2, 2, 4, 4)
+	require.NoError(t, err)
+
+	assert.True(t, grid.Contains(Location{2, 2, true}))
+	assert.True(t, grid.Contains(Location{4, 4, true}))
+	assert.False(t, grid.Contains(Location{1, 3, true}))
+	assert.False(t, grid.Contains(Location{5, 4, true}))
+	assert.False(t, grid.Contains(Location{}))
+}
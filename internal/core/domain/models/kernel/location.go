@@ -1,10 +1,10 @@
 package kernel
 
 import (
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/random"
 	"errors"
 	"fmt"
-	"math/rand"
-	"time"
 )
 
 type Location struct {
@@ -14,23 +14,12 @@ type Location struct {
 	isSet bool
 }
 
-const (
-	maxX = 10
-	minX = 1
-	maxY = 10
-	minY = 1
-)
-
 var (
-	randomSource         *rand.Rand
 	ErrValueIsOutOfRange = errors.New("value is out of range")
 	ErrInvalidLocation   = errors.New("location is invalid")
+	ErrInvalidGrid       = errors.New("grid is invalid")
 )
 
-func init() {
-	randomSource = rand.New(rand.NewSource(time.Now().UnixNano()))
-}
-
 func abs(x int) int {
 	if x < 0 {
 		return -x
@@ -39,22 +28,35 @@ func abs(x int) int {
 	return x
 }
 
-func NewLocation(x int, y int) (Location, error) {
-	if x < minX || x > maxX {
+// NewLocation создает точку, лежащую внутри сетки города
+func NewLocation(grid Grid, x int, y int) (Location, error) {
+	if grid.IsEmpty() {
+		return Location{}, ErrInvalidGrid
+	}
+	if x < grid.minX || x > grid.maxX {
 		return Location{}, ErrValueIsOutOfRange
 	}
-	if y < minY || y > maxY {
+	if y < grid.minY || y > grid.maxY {
 		return Location{}, ErrValueIsOutOfRange
 	}
 
 	return Location{x, y, true}, nil
 }
 
-func NewRandomLocation() (Location, error) {
-	x := randomSource.Intn(maxX-minX+1) + minX
-	y := randomSource.Intn(maxY-minY+1) + minY
+// NewRandomLocation выбирает точку сетки с помощью source: с детерминированным
+// источником результат воспроизводим
+func NewRandomLocation(grid Grid, source random.Source) (Location, error) {
+	if grid.IsEmpty() {
+		return Location{}, ErrInvalidGrid
+	}
+	if source == nil {
+		return Location{}, errs.NewValueIsRequiredError("source")
+	}
 
-	location, err := NewLocation(x, y)
+	x := source.Intn(grid.Width()) + grid.minX
+	y := source.Intn(grid.Height()) + grid.minY
+
+	location, err := NewLocation(grid, x, y)
 	if err != nil {
 		panic(fmt.Sprintf("invalid random location: x=%d, y=%d, err=%v", x, y, err))
 
@@ -64,6 +66,16 @@ func NewRandomLocation() (Location, error) {
 
 }
 
+// RestoreLocation восстанавливает сохраненную точку без проверки границ сетки:
+// уменьшение сетки не должно мешать читать уже созданные заказы и курьеров.
+// Проверяется только то, что координаты положительные, как в любой сетке.
+func RestoreLocation(x int, y int) (Location, error) {
+	if x < minCoordinate || y < minCoordinate {
+		return Location{}, ErrValueIsOutOfRange
+	}
+	return Location{x, y, true}, nil
+}
+
 func (l Location) X() int {
 	return l.x
 }
@@ -1,28 +1,62 @@
 package kernel
 
 import (
+	"delivery/internal/pkg/random"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewValidLocation(t *testing.T) {
-	location, err := NewLocation(3, 7)
+	location, err := NewLocation(DefaultGrid(), 3, 7)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, location)
 }
 
 func TestNewInvalidLocation(t *testing.T) {
-	location, err := NewLocation(-1, 1)
+	location, err := NewLocation(DefaultGrid(), -1, 1)
 
 	assert.ErrorIs(t, err, ErrValueIsOutOfRange)
 	assert.Equal(t, location, Location{})
 }
 
+func TestNewLocationOutsideDefaultGridFitsPilotCity(t *testing.T) {
+	pilotCity, err := NewGridOfSize(200, 150)
+	assert.NoError(t, err)
+
+	_, err = NewLocation(DefaultGrid(), 150, 120)
+	assert.ErrorIs(t, err, ErrValueIsOutOfRange)
+
+	location, err := NewLocation(pilotCity, 150, 120)
+	assert.NoError(t, err)
+	assert.Equal(t, 150, location.X())
+	assert.Equal(t, 120, location.Y())
+
+	_, err = NewLocation(pilotCity, 150, 151)
+	assert.ErrorIs(t, err, ErrValueIsOutOfRange)
+}
+
+func TestNewLocationWithEmptyGrid(t *testing.T) {
+	_, err := NewLocation(Grid{}, 1, 1)
+
+	assert.ErrorIs(t, err, ErrInvalidGrid)
+}
+
+func TestRestoreLocationOutsideGrid(t *testing.T) {
+	location, err := RestoreLocation(200, 150)
+
+	assert.NoError(t, err)
+	assert.False(t, location.IsEmpty())
+	assert.False(t, DefaultGrid().Contains(location))
+
+	_, err = RestoreLocation(0, 1)
+	assert.ErrorIs(t, err, ErrValueIsOutOfRange)
+}
+
 func TestCalculateCorrectDistance(t *testing.T) {
-	firstLocation, _ := NewLocation(1, 1)
-	secondLocation, _ := NewLocation(5, 5)
+	firstLocation, _ := NewLocation(DefaultGrid(), 1, 1)
+	secondLocation, _ := NewLocation(DefaultGrid(), 5, 5)
 	distance, err := firstLocation.DistanceTo(secondLocation)
 
 	assert.Equal(t, distance, 8)
@@ -30,7 +64,7 @@ func TestCalculateCorrectDistance(t *testing.T) {
 }
 
 func TestCalculateDistanceInvalidTarget(t *testing.T) {
-	firstLocation, _ := NewLocation(1, 1)
+	firstLocation, _ := NewLocation(DefaultGrid(), 1, 1)
 
 	distance, err := firstLocation.DistanceTo(Location{})
 
@@ -39,14 +73,44 @@ func TestCalculateDistanceInvalidTarget(t *testing.T) {
 }
 
 func TestRandomGeneration(t *testing.T) {
-	for range 1000 {
-		location, _ := NewRandomLocation()
+	t.Run("default grid", func(t *testing.T) {
+		grid := DefaultGrid()
+		for range 1000 {
+			location, _ := NewRandomLocation(grid, random.System())
+
+			assert.False(t, location.IsEmpty())
+			assert.GreaterOrEqual(t, location.X(), 1)
+			assert.LessOrEqual(t, location.X(), 10)
+			assert.GreaterOrEqual(t, location.Y(), 1)
+			assert.LessOrEqual(t, location.Y(), 10)
+		}
+	})
+
+	t.Run("custom grid", func(t *testing.T) {
+		grid, err := NewGridOfSize(200, 150)
+		assert.NoError(t, err)
+
+		for range 1000 {
+			location, _ := NewRandomLocation(grid, random.System())
 
-		assert.False(t, location.IsEmpty())
-		assert.GreaterOrEqual(t, location.X(), minX)
-		assert.LessOrEqual(t, location.X(), maxX)
-		assert.GreaterOrEqual(t, location.Y(), minY)
-		assert.LessOrEqual(t, location.Y(), maxY)
+			assert.False(t, location.IsEmpty())
+			assert.True(t, grid.Contains(location))
+		}
+	})
+}
+
+func TestRandomGenerationIsReproducible(t *testing.T) {
+	first, err := NewRandomLocation(DefaultGrid(), random.NewSeeded(42))
+	assert.NoError(t, err)
+	second, err := NewRandomLocation(DefaultGrid(), random.NewSeeded(42))
+	assert.NoError(t, err)
+	assert.True(t, first.Equals(second))
+
+	location, err := NewRandomLocation(DefaultGrid(), random.NewFake(2, 6))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, location.X())
+	assert.Equal(t, 7, location.Y())
 
-	}
+	_, err = NewRandomLocation(DefaultGrid(), nil)
+	assert.Error(t, err)
 }
@@ -0,0 +1,194 @@
+package kernel
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Path — клетки маршрута по порядку, без начальной точки. Каждый шаг ведет в
+// соседнюю по стороне клетку.
+type Path []Location
+
+func (p Path) Length() int {
+	return len(p)
+}
+
+// FindPath ищет кратчайший проходимый маршрут, считая все шаги равными
+func (m CityMap) FindPath(from Location, to Location) (Path, error) {
+	return m.FindRoute(from, to, CostMap{}, Pedestrian)
+}
+
+// FindRoute ищет самый дешевый для transport проходимый маршрут алгоритмом A*
+// с манхэттенской эвристикой: она допустима, так как шаг стоит не меньше 1.
+// Стартовая клетка может быть непроходимой: карта могла измениться, пока
+// курьер в ней стоял. Если маршрута нет, возвращает ErrNoPath.
+func (m CityMap) FindRoute(from Location, to Location, costs CostMap, transport Transport) (Path, error) {
+	if from.IsEmpty() || to.IsEmpty() {
+		return nil, ErrInvalidLocation
+	}
+	if !m.grid.Contains(from) {
+		return nil, ErrValueIsOutOfRange
+	}
+	if !m.IsPassable(to) {
+		return nil, ErrNoPath
+	}
+	if from.Equals(to) {
+		return Path{}, nil
+	}
+
+	// Без пробок привычный маршрут «сначала по X, потом по Y» тоже кратчайший,
+	// поэтому на открытой местности курьеры ходят как раньше, без поиска
+	if costs.IsEmpty() {
+		if path, ok := m.straightPath(from, to); ok {
+			return path, nil
+		}
+	}
+	return m.searchPath(from, to, costs, transport)
+}
+
+func (m CityMap) straightPath(from Location, to Location) (Path, bool) {
+	path := make(Path, 0, abs(to.x-from.x)+abs(to.y-from.y))
+	x, y := from.x, from.y
+	for x != to.x {
+		x += sign(to.x - x)
+		path = append(path, Location{x, y, true})
+	}
+	for y != to.y {
+		y += sign(to.y - y)
+		path = append(path, Location{x, y, true})
+	}
+
+	for _, location := range path {
+		if !m.IsPassable(location) {
+			return nil, false
+		}
+	}
+	return path, true
+}
+
+func (m CityMap) searchPath(from Location, to Location, costs CostMap, transport Transport) (Path, error) {
+	cells := m.grid.Width() * m.grid.Height()
+	cost := make([]float64, cells)
+	previous := make([]int, cells)
+	for i := range cells {
+		cost[i] = math.Inf(1)
+		previous[i] = -1
+	}
+
+	start, target := m.index(from), m.index(to)
+	cost[start] = 0
+	open := &pathQueue{}
+	heap.Push(open, pathNode{index: start, priority: float64(from.manhattan(to))})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(pathNode)
+		if current.index == target {
+			return m.buildPath(previous, start, target), nil
+		}
+		if current.cost > cost[current.index] {
+			continue
+		}
+
+		for _, neighbour := range m.neighbours(m.location(current.index)) {
+			next := m.index(neighbour)
+			nextCost := cost[current.index] + costs.StepCost(neighbour, transport)
+			if cost[next] <= nextCost {
+				continue
+			}
+			cost[next] = nextCost
+			previous[next] = current.index
+			heap.Push(open, pathNode{
+				index:    next,
+				cost:     nextCost,
+				priority: nextCost + float64(neighbour.manhattan(to)),
+				sequence: open.sequence,
+			})
+		}
+	}
+	return nil, ErrNoPath
+}
+
+func (m CityMap) buildPath(previous []int, start int, target int) Path {
+	var path Path
+	for index := target; index != start; index = previous[index] {
+		path = append(path, m.location(index))
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func (m CityMap) neighbours(location Location) []Location {
+	neighbours := make([]Location, 0, 4)
+	for _, step := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		neighbour := Location{location.x + step[0], location.y + step[1], true}
+		if m.IsPassable(neighbour) {
+			neighbours = append(neighbours, neighbour)
+		}
+	}
+	return neighbours
+}
+
+func (m CityMap) index(location Location) int {
+	return (location.y-m.grid.minY)*m.grid.Width() + location.x - m.grid.minX
+}
+
+func (m CityMap) location(index int) Location {
+	return Location{index%m.grid.Width() + m.grid.minX, index/m.grid.Width() + m.grid.minY, true}
+}
+
+func (l Location) manhattan(other Location) int {
+	return abs(l.x-other.x) + abs(l.y-other.y)
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+type pathNode struct {
+	index    int
+	cost     float64
+	priority float64
+	sequence int
+}
+
+// pathQueue — очередь с приоритетом для A*. При равной оценке раньше
+// извлекается узел, добавленный раньше: маршрут не зависит от устройства кучи.
+type pathQueue struct {
+	nodes    []pathNode
+	sequence int
+}
+
+func (q *pathQueue) Len() int {
+	return len(q.nodes)
+}
+
+func (q *pathQueue) Less(i, j int) bool {
+	if q.nodes[i].priority != q.nodes[j].priority {
+		return q.nodes[i].priority < q.nodes[j].priority
+	}
+	return q.nodes[i].sequence < q.nodes[j].sequence
+}
+
+func (q *pathQueue) Swap(i, j int) {
+	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
+}
+
+func (q *pathQueue) Push(node any) {
+	q.nodes = append(q.nodes, node.(pathNode))
+	q.sequence++
+}
+
+func (q *pathQueue) Pop() any {
+	last := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return last
+}
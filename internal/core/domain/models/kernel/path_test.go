@@ -0,0 +1,126 @@
+package kernel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertConnected(t *testing.T, cityMap CityMap, from Location, path Path) {
+	t.Helper()
+	previous := from
+	for _, location := range path {
+		assert.Equal(t, 1, previous.manhattan(location))
+		assert.True(t, cityMap.IsPassable(location))
+		previous = location
+	}
+}
+
+func TestCityMap_FindPath(t *testing.T) {
+	t.Run("open map goes along X then Y", func(t *testing.T) {
+		cityMap := mustCreateCityMap(t, DefaultGrid())
+
+		path, err := cityMap.FindPath(Location{1, 1, true}, Location{3, 2, true})
+
+		require.NoError(t, err)
+		assert.Equal(t, Path{{2, 1, true}, {3, 1, true}, {3, 2, true}}, path)
+	})
+
+	t.Run("same location", func(t *testing.T) {
+		cityMap := mustCreateCityMap(t, DefaultGrid())
+
+		path, err := cityMap.FindPath(Location{4, 4, true}, Location{4, 4, true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, path.Length())
+	})
+
+	t.Run("goes around wall", func(t *testing.T) {
+		// Стена x = 5, y = 1..9, проход только через (5, 10)
+		var wall []Location
+		for y := 1; y <= 9; y++ {
+			wall = append(wall, Location{5, y, true})
+		}
+		cityMap := mustCreateCityMap(t, DefaultGrid(), wall...)
+		from, to := Location{4, 2, true}, Location{6, 2, true}
+
+		path, err := cityMap.FindPath(from, to)
+
+		require.NoError(t, err)
+		assert.Equal(t, 18, path.Length())
+		assert.Equal(t, to, path[path.Length()-1])
+		assertConnected(t, cityMap, from, path)
+	})
+
+	t.Run("shortest of several detours", func(t *testing.T) {
+		grid, err := NewGridOfSize(200, 150)
+		require.NoError(t, err)
+		// Перекрыта улица y = 75 кроме проездов x = 20 и x = 150
+		var street []Location
+		for x := 1; x <= 200; x++ {
+			if x != 20 && x != 150 {
+				street = append(street, Location{x, 75, true})
+			}
+		}
+		cityMap := mustCreateCityMap(t, grid, street...)
+		from, to := Location{140, 10, true}, Location{130, 140, true}
+
+		path, err := cityMap.FindPath(from, to)
+
+		require.NoError(t, err)
+		assert.Equal(t, 10+20+130, path.Length())
+		assertConnected(t, cityMap, from, path)
+	})
+
+	t.Run("starts from blocked cell", func(t *testing.T) {
+		cityMap := mustCreateCityMap(t, DefaultGrid(), Location{2, 2, true}, Location{3, 2, true})
+
+		path, err := cityMap.FindPath(Location{2, 2, true}, Location{4, 2, true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, path.Length())
+	})
+
+	t.Run("no path to enclosed cell", func(t *testing.T) {
+		cityMap := mustCreateCityMap(t, DefaultGrid(), Location{1, 2, true}, Location{2, 1, true})
+
+		_, err := cityMap.FindPath(Location{5, 5, true}, Location{1, 1, true})
+
+		assert.ErrorIs(t, err, ErrNoPath)
+	})
+
+	t.Run("no path to blocked cell", func(t *testing.T) {
+		cityMap := mustCreateCityMap(t, DefaultGrid(), Location{1, 1, true})
+
+		_, err := cityMap.FindPath(Location{5, 5, true}, Location{1, 1, true})
+
+		assert.ErrorIs(t, err, ErrNoPath)
+	})
+
+	t.Run("invalid locations", func(t *testing.T) {
+		cityMap := mustCreateCityMap(t, DefaultGrid())
+
+		_, err := cityMap.FindPath(Location{}, Location{1, 1, true})
+		assert.ErrorIs(t, err, ErrInvalidLocation)
+
+		_, err = cityMap.FindPath(Location{11, 1, true}, Location{1, 1, true})
+		assert.ErrorIs(t, err, ErrValueIsOutOfRange)
+	})
+}
+
+func TestPathDistance(t *testing.T) {
+	_, err := NewPathDistance(CityMap{}, CostMap{})
+	assert.Error(t, err)
+
+	open, err := NewPathDistance(mustCreateCityMap(t, DefaultGrid()), CostMap{})
+	require.NoError(t, err)
+	distance, err := open.Between(Location{1, 1, true}, Location{5, 5, true}, Car)
+	require.NoError(t, err)
+	assert.Equal(t, 8.0, distance)
+
+	blocked, err := NewPathDistance(mustCreateCityMap(t, DefaultGrid(), Location{1, 2, true}, Location{2, 1, true}), CostMap{})
+	require.NoError(t, err)
+	_, err = blocked.Between(Location{5, 5, true}, Location{1, 1, true}, Car)
+	assert.ErrorIs(t, err, ErrNoPath)
+}
@@ -0,0 +1,50 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"slices"
+)
+
+// Transport — вид транспорта курьера. От него зависит, как на курьера влияют
+// пробки и рельеф.
+type Transport int
+
+const (
+	Pedestrian Transport = iota
+	Bicycle
+	Car
+)
+
+// Transports перечисляет все виды транспорта
+var Transports = []Transport{Pedestrian, Bicycle, Car}
+
+// IsValid сообщает, что это один из известных видов транспорта
+func (t Transport) IsValid() bool {
+	return slices.Contains(Transports, t)
+}
+
+func (t Transport) String() string {
+	switch t {
+	case Pedestrian:
+		return "pedestrian"
+	case Bicycle:
+		return "bicycle"
+	case Car:
+		return "car"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseTransport(s string) (Transport, error) {
+	switch s {
+	case "pedestrian":
+		return Pedestrian, nil
+	case "bicycle":
+		return Bicycle, nil
+	case "car":
+		return Car, nil
+	default:
+		return 0, errs.NewValueIsInvalidError("transport")
+	}
+}
@@ -0,0 +1,27 @@
+package kernel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_IsValid(t *testing.T) {
+	for _, transport := range Transports {
+		assert.True(t, transport.IsValid())
+	}
+	assert.False(t, Transport(-1).IsValid())
+	assert.False(t, Transport(len(Transports)).IsValid())
+}
+
+func TestParseTransport(t *testing.T) {
+	for _, transport := range Transports {
+		parsed, err := ParseTransport(transport.String())
+		require.NoError(t, err)
+		assert.Equal(t, transport, parsed)
+	}
+
+	_, err := ParseTransport("truck")
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package order
 
 import (
 	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/pkg/ddd"
 	"delivery/internal/pkg/errs"
 	"errors"
 	"math"
@@ -12,10 +13,11 @@ import (
 var (
 	ErrCannotCompleteNotAssignedOrder   = errors.New("can not complete not assigned order")
 	ErrCannotAssignAlreadyAssignedOrder = errors.New("can not assign already assigned order")
+	ErrCannotReleaseNotAssignedOrder    = errors.New("can not release not assigned order")
 )
 
 type Order struct {
-	id        uuid.UUID
+	*ddd.BaseAggregate[uuid.UUID]
 	courierID *uuid.UUID
 	location  kernel.Location
 	volume    int
@@ -33,16 +35,24 @@ func NewOrder(orderID uuid.UUID, location kernel.Location, volume int) (*Order,
 		return nil, errs.NewValueIsOutOfRangeError("volume", volume, 1, math.MaxInt)
 	}
 
-	return &Order{
-		id:       orderID,
-		location: location,
-		volume:   volume,
-		status:   Created,
-	}, nil
+	aggregate := &Order{
+		BaseAggregate: ddd.NewBaseAggregate(orderID),
+		location:      location,
+		volume:        volume,
+		status:        Created,
+	}
+	aggregate.RaiseDomainEvent(NewOrderCreatedDomainEvent(aggregate))
+	return aggregate, nil
 }
 
-func (o *Order) ID() uuid.UUID {
-	return o.id
+func RestoreOrder(id uuid.UUID, courierID *uuid.UUID, location kernel.Location, volume int, status Status, version int) *Order {
+	return &Order{
+		BaseAggregate: ddd.RestoreBaseAggregate(id, version),
+		courierID:     courierID,
+		location:      location,
+		volume:        volume,
+		status:        status,
+	}
 }
 
 func (o *Order) CourierID() *uuid.UUID {
@@ -57,15 +67,15 @@ func (o *Order) Volume() int {
 	return o.volume
 }
 
-func (o *Order) Status() int {
-	return int(o.status)
+func (o *Order) Status() string {
+	return o.status.String()
 }
 
 func (o *Order) Equals(other *Order) bool {
 	if other == nil {
 		return false
 	}
-	return o.id == other.id
+	return o.ID() == other.ID()
 }
 
 func (o *Order) Assign(courierID uuid.UUID) error {
@@ -79,6 +89,7 @@ func (o *Order) Assign(courierID uuid.UUID) error {
 
 	o.courierID = &courierID
 	o.status = Assigned
+	o.RaiseDomainEvent(NewOrderAssignedDomainEvent(o))
 	return nil
 }
 
@@ -88,5 +99,20 @@ func (o *Order) Complete() error {
 	}
 
 	o.status = Completed
+	o.RaiseDomainEvent(NewOrderCompletedDomainEvent(o))
+	return nil
+}
+
+// Release снимает заказ с курьера, который не может до него добраться, и
+// возвращает заказ в очередь на назначение
+func (o *Order) Release() error {
+	if o.status != Assigned || o.courierID == nil {
+		return ErrCannotReleaseNotAssignedOrder
+	}
+
+	courierID := *o.courierID
+	o.courierID = nil
+	o.status = Created
+	o.RaiseDomainEvent(NewOrderReleasedDomainEvent(o, courierID))
 	return nil
 }
@@ -0,0 +1,42 @@
+package order
+
+import (
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &OrderAssignedDomainEvent{}
+
+// OrderAssignedDomainEvent — заказ назначен на курьера
+type OrderAssignedDomainEvent struct {
+	ID          uuid.UUID
+	OrderID     uuid.UUID
+	OrderStatus string
+	CourierID   uuid.UUID
+}
+
+func NewOrderAssignedDomainEvent(aggregate *Order) *OrderAssignedDomainEvent {
+	return &OrderAssignedDomainEvent{
+		ID:          uuid.New(),
+		OrderID:     aggregate.ID(),
+		OrderStatus: aggregate.Status(),
+		CourierID:   *aggregate.CourierID(),
+	}
+}
+
+func (e *OrderAssignedDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *OrderAssignedDomainEvent) GetName() string {
+	return "OrderAssignedDomainEvent"
+}
+
+func (e *OrderAssignedDomainEvent) GetOrderID() uuid.UUID {
+	return e.OrderID
+}
+
+func (e *OrderAssignedDomainEvent) GetOrderStatus() string {
+	return e.OrderStatus
+}
@@ -0,0 +1,40 @@
+package order
+
+import (
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &OrderCompletedDomainEvent{}
+
+// OrderCompletedDomainEvent — заказ доставлен клиенту
+type OrderCompletedDomainEvent struct {
+	ID          uuid.UUID
+	OrderID     uuid.UUID
+	OrderStatus string
+}
+
+func NewOrderCompletedDomainEvent(aggregate *Order) *OrderCompletedDomainEvent {
+	return &OrderCompletedDomainEvent{
+		ID:          uuid.New(),
+		OrderID:     aggregate.ID(),
+		OrderStatus: aggregate.Status(),
+	}
+}
+
+func (e *OrderCompletedDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *OrderCompletedDomainEvent) GetName() string {
+	return "OrderCompletedDomainEvent"
+}
+
+func (e *OrderCompletedDomainEvent) GetOrderID() uuid.UUID {
+	return e.OrderID
+}
+
+func (e *OrderCompletedDomainEvent) GetOrderStatus() string {
+	return e.OrderStatus
+}
@@ -0,0 +1,40 @@
+package order
+
+import (
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &OrderCreatedDomainEvent{}
+
+// OrderCreatedDomainEvent — заказ создан и ждет назначения на курьера
+type OrderCreatedDomainEvent struct {
+	ID          uuid.UUID
+	OrderID     uuid.UUID
+	OrderStatus string
+}
+
+func NewOrderCreatedDomainEvent(aggregate *Order) *OrderCreatedDomainEvent {
+	return &OrderCreatedDomainEvent{
+		ID:          uuid.New(),
+		OrderID:     aggregate.ID(),
+		OrderStatus: aggregate.Status(),
+	}
+}
+
+func (e *OrderCreatedDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *OrderCreatedDomainEvent) GetName() string {
+	return "OrderCreatedDomainEvent"
+}
+
+func (e *OrderCreatedDomainEvent) GetOrderID() uuid.UUID {
+	return e.OrderID
+}
+
+func (e *OrderCreatedDomainEvent) GetOrderStatus() string {
+	return e.OrderStatus
+}
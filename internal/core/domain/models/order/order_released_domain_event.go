@@ -0,0 +1,43 @@
+package order
+
+import (
+	"delivery/internal/pkg/ddd"
+
+	"github.com/google/uuid"
+)
+
+var _ ddd.DomainEvent = &OrderReleasedDomainEvent{}
+
+// OrderReleasedDomainEvent — курьер не может добраться до заказа, и заказ
+// снова ждет назначения
+type OrderReleasedDomainEvent struct {
+	ID          uuid.UUID
+	OrderID     uuid.UUID
+	OrderStatus string
+	CourierID   uuid.UUID
+}
+
+func NewOrderReleasedDomainEvent(aggregate *Order, courierID uuid.UUID) *OrderReleasedDomainEvent {
+	return &OrderReleasedDomainEvent{
+		ID:          uuid.New(),
+		OrderID:     aggregate.ID(),
+		OrderStatus: aggregate.Status(),
+		CourierID:   courierID,
+	}
+}
+
+func (e *OrderReleasedDomainEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *OrderReleasedDomainEvent) GetName() string {
+	return "OrderReleasedDomainEvent"
+}
+
+func (e *OrderReleasedDomainEvent) GetOrderID() uuid.UUID {
+	return e.OrderID
+}
+
+func (e *OrderReleasedDomainEvent) GetOrderStatus() string {
+	return e.OrderStatus
+}
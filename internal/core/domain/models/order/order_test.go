@@ -2,6 +2,9 @@ package order
 
 import (
 	"delivery/internal/core/domain/models/kernel"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/outbox"
+	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
@@ -198,6 +201,47 @@ func TestOrder_Complete(t *testing.T) {
 	})
 }
 
+func TestOrder_Release(t *testing.T) {
+	t.Run("release assigned order", func(t *testing.T) {
+		order, err := NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 10)
+		require.NoError(t, err)
+		courierID := uuid.New()
+		require.NoError(t, order.Assign(courierID))
+		order.ClearDomainEvents()
+
+		require.NoError(t, order.Release())
+
+		assert.Equal(t, Status(Created).String(), order.Status())
+		assert.Nil(t, order.CourierID())
+		events := order.GetDomainEvents()
+		require.Len(t, events, 1)
+		released, ok := events[0].(*OrderReleasedDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, order.ID(), released.GetOrderID())
+		assert.Equal(t, courierID, released.CourierID)
+		assert.Equal(t, Status(Created).String(), released.GetOrderStatus())
+
+		require.NoError(t, order.Assign(uuid.New()))
+	})
+
+	t.Run("cannot release created order", func(t *testing.T) {
+		order, err := NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, ErrCannotReleaseNotAssignedOrder, order.Release())
+	})
+
+	t.Run("cannot release completed order", func(t *testing.T) {
+		order, err := NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 10)
+		require.NoError(t, err)
+		require.NoError(t, order.Assign(uuid.New()))
+		require.NoError(t, order.Complete())
+
+		assert.Equal(t, ErrCannotReleaseNotAssignedOrder, order.Release())
+		assert.Equal(t, Status(Completed).String(), order.Status())
+	})
+}
+
 func TestOrder_Equals(t *testing.T) {
 	order1, err := NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 10)
 	require.NoError(t, err)
@@ -252,7 +296,86 @@ func TestOrder_StatusTransitions(t *testing.T) {
 
 // Helper function to create location for testing
 func mustCreateLocation(t *testing.T, x, y int) kernel.Location {
-	location, err := kernel.NewLocation(x, y)
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), x, y)
 	require.NoError(t, err)
 	return location
 }
+
+func TestOrder_DomainEvents(t *testing.T) {
+	t.Run("new order raises created event", func(t *testing.T) {
+		order, err := NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 10)
+		require.NoError(t, err)
+
+		events := order.GetDomainEvents()
+		require.Len(t, events, 1)
+		created, ok := events[0].(*OrderCreatedDomainEvent)
+		require.True(t, ok)
+		assert.NotEqual(t, uuid.Nil, created.GetID())
+		assert.Equal(t, order.ID(), created.GetOrderID())
+		assert.Equal(t, Status(Created).String(), created.GetOrderStatus())
+	})
+
+	t.Run("assign and complete raise events", func(t *testing.T) {
+		order, err := NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 10)
+		require.NoError(t, err)
+		order.ClearDomainEvents()
+
+		courierID := uuid.New()
+		require.NoError(t, order.Assign(courierID))
+		require.NoError(t, order.Complete())
+
+		events := order.GetDomainEvents()
+		require.Len(t, events, 2)
+
+		assigned, ok := events[0].(*OrderAssignedDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, order.ID(), assigned.GetOrderID())
+		assert.Equal(t, courierID, assigned.CourierID)
+		assert.Equal(t, Status(Assigned).String(), assigned.GetOrderStatus())
+
+		completed, ok := events[1].(*OrderCompletedDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, order.ID(), completed.GetOrderID())
+		assert.Equal(t, Status(Completed).String(), completed.GetOrderStatus())
+	})
+
+	t.Run("failed transition raises nothing", func(t *testing.T) {
+		order, err := NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 10)
+		require.NoError(t, err)
+		order.ClearDomainEvents()
+
+		assert.Error(t, order.Complete())
+		assert.Error(t, order.Assign(uuid.Nil))
+		assert.Empty(t, order.GetDomainEvents())
+	})
+
+	t.Run("restored order has no events", func(t *testing.T) {
+		order := RestoreOrder(uuid.New(), nil, mustCreateLocation(t, 5, 5), 10, Created, 1)
+		assert.Empty(t, order.GetDomainEvents())
+	})
+}
+
+func TestOrder_DomainEventsRoundTripThroughOutbox(t *testing.T) {
+	registry, err := outbox.NewEventRegistry()
+	require.NoError(t, err)
+	require.NoError(t, registry.RegisterDomainEvent(reflect.TypeOf(OrderCreatedDomainEvent{})))
+	require.NoError(t, registry.RegisterDomainEvent(reflect.TypeOf(OrderAssignedDomainEvent{})))
+	require.NoError(t, registry.RegisterDomainEvent(reflect.TypeOf(OrderCompletedDomainEvent{})))
+	require.NoError(t, registry.RegisterDomainEvent(reflect.TypeOf(OrderReleasedDomainEvent{})))
+
+	order, err := NewOrder(uuid.New(), mustCreateLocation(t, 5, 5), 10)
+	require.NoError(t, err)
+	require.NoError(t, order.Assign(uuid.New()))
+	require.NoError(t, order.Release())
+	require.NoError(t, order.Assign(uuid.New()))
+	require.NoError(t, order.Complete())
+
+	for _, event := range order.GetDomainEvents() {
+		message, err := outbox.EncodeDomainEvent(event, clock.System())
+		require.NoError(t, err)
+
+		decoded, err := registry.DecodeDomainEvent(&message)
+		require.NoError(t, err)
+		assert.Equal(t, event, decoded)
+	}
+}
@@ -1,5 +1,7 @@
 package order
 
+import "delivery/internal/pkg/errs"
+
 type Status int
 
 const (
@@ -20,3 +22,16 @@ func (s Status) String() string {
 		return "Unknown"
 	}
 }
+
+func ParseStatus(s string) (Status, error) {
+	switch s {
+	case "Created":
+		return Created, nil
+	case "Assigned":
+		return Assigned, nil
+	case "Completed":
+		return Completed, nil
+	default:
+		return 0, errs.NewValueIsInvalidError("status")
+	}
+}
@@ -2,21 +2,31 @@ package services
 
 import (
 	"delivery/internal/core/domain/models/courier"
+	"delivery/internal/core/domain/models/kernel"
 	ord "delivery/internal/core/domain/models/order"
 	"delivery/internal/pkg/errs"
 	"errors"
 	"math"
 )
 
+var ErrCourierNotFound = errors.New("courier cannot be found")
+
 type OrderDispatcher interface {
 	Dispatch(order *ord.Order, couriers []*courier.Courier) (*courier.Courier, error)
 }
 
 type orderDispatcher struct {
+	distance kernel.Distance
 }
 
-func NewOrderDispatcher() OrderDispatcher {
-	return &orderDispatcher{}
+// NewOrderDispatcher создает диспетчер, выбирающий курьера по времени до заказа
+// в заданной мере расстояния
+func NewOrderDispatcher(distance kernel.Distance) (OrderDispatcher, error) {
+	if distance == nil {
+		return nil, errs.NewValueIsRequiredError("distance")
+	}
+
+	return &orderDispatcher{distance: distance}, nil
 }
 
 func (d *orderDispatcher) Dispatch(order *ord.Order, couriers []*courier.Courier) (*courier.Courier, error) {
@@ -28,11 +38,11 @@ func (d *orderDispatcher) Dispatch(order *ord.Order, couriers []*courier.Courier
 		return nil, errs.NewValueIsInvalidError("couriers")
 	}
 
-	if order.Status() != ord.Created {
+	if order.Status() != ord.Status(ord.Created).String() {
 		return nil, errors.New("order is already assigned")
 	}
 
-	courier, err := findCourier(order, couriers)
+	courier, err := d.findCourier(order, couriers)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +58,7 @@ func (d *orderDispatcher) Dispatch(order *ord.Order, couriers []*courier.Courier
 	return courier, nil
 }
 
-func findCourier(order *ord.Order, couriers []*courier.Courier) (*courier.Courier, error) {
+func (d *orderDispatcher) findCourier(order *ord.Order, couriers []*courier.Courier) (*courier.Courier, error) {
 	var bestCourier *courier.Courier
 	minTime := math.MaxFloat64
 
@@ -57,7 +67,11 @@ func findCourier(order *ord.Order, couriers []*courier.Courier) (*courier.Courie
 			continue
 		}
 
-		time, err := courier.CalculateTimeToLocation(order.Location())
+		// Курьер, от которого до заказа нет проходимого маршрута, не подходит
+		time, err := courier.CalculateTimeToLocation(order.Location(), d.distance)
+		if errors.Is(err, kernel.ErrNoPath) {
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -70,7 +84,7 @@ func findCourier(order *ord.Order, couriers []*courier.Courier) (*courier.Courie
 	}
 
 	if bestCourier == nil {
-		return nil, errors.New("courier cannot be found")
+		return nil, ErrCourierNotFound
 	}
 
 	return bestCourier, nil
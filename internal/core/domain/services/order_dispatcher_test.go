@@ -4,6 +4,7 @@ import (
 	"delivery/internal/core/domain/models/courier"
 	"delivery/internal/core/domain/models/kernel"
 	ord "delivery/internal/core/domain/models/order"
+	"delivery/internal/pkg/errs"
 	"testing"
 
 	"github.com/google/uuid"
@@ -11,8 +12,15 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestNewOrderDispatcher(t *testing.T) {
+	_, err := NewOrderDispatcher(nil)
+
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+}
+
 func TestOrderDispatcher_Dispatch(t *testing.T) {
-	dispatcher := NewOrderDispatcher()
+	dispatcher, err := NewOrderDispatcher(kernel.GridDistance{})
+	require.NoError(t, err)
 
 	t.Run("successfully dispatch order to nearest courier", func(t *testing.T) {
 		// Создаем заказ
@@ -20,10 +28,10 @@ func TestOrderDispatcher_Dispatch(t *testing.T) {
 		require.NoError(t, err)
 
 		// Создаем курьеров на разных расстояниях
-		courier1, err := courier.NewCourier("Courier 1", 10, mustCreateLocation(t, 5, 5)) // ближе
+		courier1, err := courier.NewCourier("Courier 1", kernel.Car, 10, mustCreateLocation(t, 5, 5)) // ближе
 		require.NoError(t, err)
 
-		courier2, err := courier.NewCourier("Courier 2", 10, mustCreateLocation(t, 3, 3)) // дальше
+		courier2, err := courier.NewCourier("Courier 2", kernel.Car, 10, mustCreateLocation(t, 3, 3)) // дальше
 		require.NoError(t, err)
 
 		couriers := []*courier.Courier{courier1, courier2}
@@ -35,12 +43,12 @@ func TestOrderDispatcher_Dispatch(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, assignedCourier)
 		assert.Equal(t, courier1.ID(), assignedCourier.ID()) // должен быть выбран ближайший
-		assert.Equal(t, ord.Assigned, order.Status())
+		assert.Equal(t, ord.Status(ord.Assigned).String(), order.Status())
 		assert.Equal(t, courier1.ID(), *order.CourierID())
 	})
 
 	t.Run("return error when order is nil", func(t *testing.T) {
-		courier1, err := courier.NewCourier("Courier 1", 10, mustCreateLocation(t, 5, 5))
+		courier1, err := courier.NewCourier("Courier 1", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 		require.NoError(t, err)
 
 		couriers := []*courier.Courier{courier1}
@@ -74,7 +82,7 @@ func TestOrderDispatcher_Dispatch(t *testing.T) {
 		err = order.Assign(courierID)
 		require.NoError(t, err)
 
-		courier1, err := courier.NewCourier("Courier 1", 10, mustCreateLocation(t, 5, 5))
+		courier1, err := courier.NewCourier("Courier 1", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 		require.NoError(t, err)
 		couriers := []*courier.Courier{courier1}
 
@@ -91,7 +99,7 @@ func TestOrderDispatcher_Dispatch(t *testing.T) {
 		require.NoError(t, err)
 
 		// Создаем курьера с недостаточным местом (по умолчанию 10)
-		courier1, err := courier.NewCourier("Courier 1", 10, mustCreateLocation(t, 5, 5))
+		courier1, err := courier.NewCourier("Courier 1", kernel.Car, 10, mustCreateLocation(t, 5, 5))
 		require.NoError(t, err)
 
 		couriers := []*courier.Courier{courier1}
@@ -104,8 +112,93 @@ func TestOrderDispatcher_Dispatch(t *testing.T) {
 	})
 }
 
+func TestOrderDispatcher_DispatchByDistance(t *testing.T) {
+	origin, err := kernel.NewGeoLocation(55.7558, 37.6173)
+	require.NoError(t, err)
+	geoDistance, err := kernel.NewGeoDistance(origin, 100)
+	require.NoError(t, err)
+
+	// По сетке ближе первый курьер (6 клеток против 8), по прямой — второй (6 против 5,7)
+	for name, tc := range map[string]struct {
+		distance kernel.Distance
+		expected int
+	}{
+		"grid": {distance: kernel.GridDistance{}, expected: 0},
+		"geo":  {distance: geoDistance, expected: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dispatcher, err := NewOrderDispatcher(tc.distance)
+			require.NoError(t, err)
+
+			order, err := ord.NewOrder(uuid.New(), mustCreateLocation(t, 10, 10), 5)
+			require.NoError(t, err)
+			straight, err := courier.NewCourier("Straight", kernel.Pedestrian, 1, mustCreateLocation(t, 10, 4))
+			require.NoError(t, err)
+			diagonal, err := courier.NewCourier("Diagonal", kernel.Pedestrian, 1, mustCreateLocation(t, 6, 6))
+			require.NoError(t, err)
+			couriers := []*courier.Courier{straight, diagonal}
+
+			assignedCourier, err := dispatcher.Dispatch(order, couriers)
+
+			require.NoError(t, err)
+			assert.Equal(t, couriers[tc.expected].ID(), assignedCourier.ID())
+		})
+	}
+}
+
+func TestOrderDispatcher_DispatchAroundObstacles(t *testing.T) {
+	// Стена x = 8, y = 1..9 отделяет ближнего по прямой курьера от заказа
+	var wall []kernel.Location
+	for y := 1; y <= 9; y++ {
+		wall = append(wall, mustCreateLocation(t, 8, y))
+	}
+	cityMap, err := kernel.NewCityMap(kernel.DefaultGrid(), wall)
+	require.NoError(t, err)
+	distance, err := kernel.NewPathDistance(cityMap, kernel.CostMap{})
+	require.NoError(t, err)
+	dispatcher, err := NewOrderDispatcher(distance)
+	require.NoError(t, err)
+
+	t.Run("ranks couriers by path length", func(t *testing.T) {
+		order, err := ord.NewOrder(uuid.New(), mustCreateLocation(t, 9, 1), 5)
+		require.NoError(t, err)
+		// 2 клетки по прямой, 20 в обход стены
+		behindWall, err := courier.NewCourier("Behind wall", kernel.Pedestrian, 1, mustCreateLocation(t, 7, 1))
+		require.NoError(t, err)
+		// 6 клеток по своему берегу
+		sameSide, err := courier.NewCourier("Same side", kernel.Pedestrian, 1, mustCreateLocation(t, 10, 6))
+		require.NoError(t, err)
+
+		assignedCourier, err := dispatcher.Dispatch(order, []*courier.Courier{behindWall, sameSide})
+
+		require.NoError(t, err)
+		assert.Equal(t, sameSide.ID(), assignedCourier.ID())
+	})
+
+	t.Run("skips courier without passable path", func(t *testing.T) {
+		enclosed, err := kernel.NewCityMap(kernel.DefaultGrid(), []kernel.Location{
+			mustCreateLocation(t, 1, 2), mustCreateLocation(t, 2, 1),
+		})
+		require.NoError(t, err)
+		enclosedDistance, err := kernel.NewPathDistance(enclosed, kernel.CostMap{})
+		require.NoError(t, err)
+		dispatcher, err := NewOrderDispatcher(enclosedDistance)
+		require.NoError(t, err)
+
+		order, err := ord.NewOrder(uuid.New(), mustCreateLocation(t, 1, 1), 5)
+		require.NoError(t, err)
+		outside, err := courier.NewCourier("Outside", kernel.Pedestrian, 1, mustCreateLocation(t, 5, 5))
+		require.NoError(t, err)
+
+		_, err = dispatcher.Dispatch(order, []*courier.Courier{outside})
+
+		assert.ErrorIs(t, err, ErrCourierNotFound)
+		assert.Equal(t, ord.Status(ord.Created).String(), order.Status())
+	})
+}
+
 func mustCreateLocation(t *testing.T, x, y int) kernel.Location {
-	location, err := kernel.NewLocation(x, y)
+	location, err := kernel.NewLocation(kernel.DefaultGrid(), x, y)
 	require.NoError(t, err)
 	return location
 }
@@ -0,0 +1,10 @@
+package ports
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+)
+
+type GeoClient interface {
+	GetGeolocation(ctx context.Context, street string) (kernel.Location, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/order"
+
+	"github.com/google/uuid"
+)
+
+type OrderRepository interface {
+	Add(ctx context.Context, aggregate *order.Order) error
+	Update(ctx context.Context, aggregate *order.Order) error
+	Get(ctx context.Context, ID uuid.UUID) (*order.Order, error)
+	GetOldestInCreatedStatus(ctx context.Context, limit int) ([]*order.Order, error)
+	GetAllInAssignedStatus(ctx context.Context) ([]*order.Order, error)
+}
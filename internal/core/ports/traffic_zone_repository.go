@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"delivery/internal/core/domain/models/kernel"
+)
+
+type TrafficZoneRepository interface {
+	GetAll(ctx context.Context) ([]kernel.TrafficZone, error)
+	Save(ctx context.Context, zone kernel.TrafficZone) error
+	Remove(ctx context.Context, name string) error
+}
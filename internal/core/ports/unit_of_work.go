@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+)
+
+type UnitOfWork interface {
+	Begin(ctx context.Context) error
+	Commit(ctx context.Context) error
+	Rollback() error
+	OrderRepository() OrderRepository
+	CourierRepository() CourierRepository
+}
+
+type UnitOfWorkFactory interface {
+	New() (UnitOfWork, error)
+}
@@ -0,0 +1,383 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: basket_confirmed.proto
+
+package basketconfirmedpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BasketConfirmedIntegrationEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BasketId       string                 `protobuf:"bytes,1,opt,name=BasketId,proto3" json:"BasketId,omitempty"`
+	Address        *Address               `protobuf:"bytes,2,opt,name=Address,proto3" json:"Address,omitempty"`
+	Items          []*Item                `protobuf:"bytes,3,rep,name=Items,proto3" json:"Items,omitempty"`
+	DeliveryPeriod *DeliveryPeriod        `protobuf:"bytes,4,opt,name=DeliveryPeriod,proto3" json:"DeliveryPeriod,omitempty"`
+	Volume         int32                  `protobuf:"varint,5,opt,name=Volume,proto3" json:"Volume,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BasketConfirmedIntegrationEvent) Reset() {
+	*x = BasketConfirmedIntegrationEvent{}
+	mi := &file_basket_confirmed_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BasketConfirmedIntegrationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BasketConfirmedIntegrationEvent) ProtoMessage() {}
+
+func (x *BasketConfirmedIntegrationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_basket_confirmed_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BasketConfirmedIntegrationEvent.ProtoReflect.Descriptor instead.
+func (*BasketConfirmedIntegrationEvent) Descriptor() ([]byte, []int) {
+	return file_basket_confirmed_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BasketConfirmedIntegrationEvent) GetBasketId() string {
+	if x != nil {
+		return x.BasketId
+	}
+	return ""
+}
+
+func (x *BasketConfirmedIntegrationEvent) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *BasketConfirmedIntegrationEvent) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BasketConfirmedIntegrationEvent) GetDeliveryPeriod() *DeliveryPeriod {
+	if x != nil {
+		return x.DeliveryPeriod
+	}
+	return nil
+}
+
+func (x *BasketConfirmedIntegrationEvent) GetVolume() int32 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Country       string                 `protobuf:"bytes,1,opt,name=Country,proto3" json:"Country,omitempty"`
+	City          string                 `protobuf:"bytes,2,opt,name=City,proto3" json:"City,omitempty"`
+	Street        string                 `protobuf:"bytes,3,opt,name=Street,proto3" json:"Street,omitempty"`
+	House         string                 `protobuf:"bytes,4,opt,name=House,proto3" json:"House,omitempty"`
+	Apartment     string                 `protobuf:"bytes,5,opt,name=Apartment,proto3" json:"Apartment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_basket_confirmed_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_basket_confirmed_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_basket_confirmed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetHouse() string {
+	if x != nil {
+		return x.House
+	}
+	return ""
+}
+
+func (x *Address) GetApartment() string {
+	if x != nil {
+		return x.Apartment
+	}
+	return ""
+}
+
+type Item struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	GoodId        string                 `protobuf:"bytes,2,opt,name=GoodId,proto3" json:"GoodId,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=Title,proto3" json:"Title,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=Price,proto3" json:"Price,omitempty"`
+	Quantity      int32                  `protobuf:"varint,5,opt,name=Quantity,proto3" json:"Quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_basket_confirmed_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_basket_confirmed_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_basket_confirmed_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Item) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Item) GetGoodId() string {
+	if x != nil {
+		return x.GoodId
+	}
+	return ""
+}
+
+func (x *Item) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Item) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Item) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type DeliveryPeriod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int32                  `protobuf:"varint,1,opt,name=From,proto3" json:"From,omitempty"`
+	To            int32                  `protobuf:"varint,2,opt,name=To,proto3" json:"To,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryPeriod) Reset() {
+	*x = DeliveryPeriod{}
+	mi := &file_basket_confirmed_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryPeriod) ProtoMessage() {}
+
+func (x *DeliveryPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_basket_confirmed_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryPeriod.ProtoReflect.Descriptor instead.
+func (*DeliveryPeriod) Descriptor() ([]byte, []int) {
+	return file_basket_confirmed_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeliveryPeriod) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *DeliveryPeriod) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+var File_basket_confirmed_proto protoreflect.FileDescriptor
+
+const file_basket_confirmed_proto_rawDesc = "" +
+	"\n" +
+	"\x16basket_confirmed.proto\"\xcf\x01\n" +
+	"\x1fBasketConfirmedIntegrationEvent\x12\x1a\n" +
+	"\bBasketId\x18\x01 \x01(\tR\bBasketId\x12\"\n" +
+	"\aAddress\x18\x02 \x01(\v2\b.AddressR\aAddress\x12\x1b\n" +
+	"\x05Items\x18\x03 \x03(\v2\x05.ItemR\x05Items\x127\n" +
+	"\x0eDeliveryPeriod\x18\x04 \x01(\v2\x0f.DeliveryPeriodR\x0eDeliveryPeriod\x12\x16\n" +
+	"\x06Volume\x18\x05 \x01(\x05R\x06Volume\"\x83\x01\n" +
+	"\aAddress\x12\x18\n" +
+	"\aCountry\x18\x01 \x01(\tR\aCountry\x12\x12\n" +
+	"\x04City\x18\x02 \x01(\tR\x04City\x12\x16\n" +
+	"\x06Street\x18\x03 \x01(\tR\x06Street\x12\x14\n" +
+	"\x05House\x18\x04 \x01(\tR\x05House\x12\x1c\n" +
+	"\tApartment\x18\x05 \x01(\tR\tApartment\"v\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x16\n" +
+	"\x06GoodId\x18\x02 \x01(\tR\x06GoodId\x12\x14\n" +
+	"\x05Title\x18\x03 \x01(\tR\x05Title\x12\x14\n" +
+	"\x05Price\x18\x04 \x01(\x01R\x05Price\x12\x1a\n" +
+	"\bQuantity\x18\x05 \x01(\x05R\bQuantity\"4\n" +
+	"\x0eDeliveryPeriod\x12\x12\n" +
+	"\x04From\x18\x01 \x01(\x05R\x04From\x12\x0e\n" +
+	"\x02To\x18\x02 \x01(\x05R\x02ToB\x1aZ\x18queues/basketconfirmedpbb\x06proto3"
+
+var (
+	file_basket_confirmed_proto_rawDescOnce sync.Once
+	file_basket_confirmed_proto_rawDescData []byte
+)
+
+func file_basket_confirmed_proto_rawDescGZIP() []byte {
+	file_basket_confirmed_proto_rawDescOnce.Do(func() {
+		file_basket_confirmed_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_basket_confirmed_proto_rawDesc), len(file_basket_confirmed_proto_rawDesc)))
+	})
+	return file_basket_confirmed_proto_rawDescData
+}
+
+var file_basket_confirmed_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_basket_confirmed_proto_goTypes = []any{
+	(*BasketConfirmedIntegrationEvent)(nil), // 0: BasketConfirmedIntegrationEvent
+	(*Address)(nil),                         // 1: Address
+	(*Item)(nil),                            // 2: Item
+	(*DeliveryPeriod)(nil),                  // 3: DeliveryPeriod
+}
+var file_basket_confirmed_proto_depIdxs = []int32{
+	1, // 0: BasketConfirmedIntegrationEvent.Address:type_name -> Address
+	2, // 1: BasketConfirmedIntegrationEvent.Items:type_name -> Item
+	3, // 2: BasketConfirmedIntegrationEvent.DeliveryPeriod:type_name -> DeliveryPeriod
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_basket_confirmed_proto_init() }
+func file_basket_confirmed_proto_init() {
+	if File_basket_confirmed_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_basket_confirmed_proto_rawDesc), len(file_basket_confirmed_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_basket_confirmed_proto_goTypes,
+		DependencyIndexes: file_basket_confirmed_proto_depIdxs,
+		MessageInfos:      file_basket_confirmed_proto_msgTypes,
+	}.Build()
+	File_basket_confirmed_proto = out.File
+	file_basket_confirmed_proto_goTypes = nil
+	file_basket_confirmed_proto_depIdxs = nil
+}
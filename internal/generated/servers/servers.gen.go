@@ -0,0 +1,826 @@
+// Package servers provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package servers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for Transport.
+const (
+	Bicycle    Transport = "bicycle"
+	Car        Transport = "car"
+	Pedestrian Transport = "pedestrian"
+)
+
+// Courier defines model for Courier.
+type Courier struct {
+	// Id Идентификатор
+	Id       openapi_types.UUID `json:"id"`
+	Location Location           `json:"location"`
+
+	// Name Имя
+	Name string `json:"name"`
+
+	// Transport Вид транспорта курьера
+	Transport Transport `json:"transport"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	// Code Код ошибки
+	Code int32 `json:"code"`
+
+	// Message Текст ошибки
+	Message string `json:"message"`
+}
+
+// Location defines model for Location.
+type Location struct {
+	// X X
+	X int `json:"x"`
+
+	// Y Y
+	Y int `json:"y"`
+}
+
+// NewCourier defines model for NewCourier.
+type NewCourier struct {
+	// Name Имя
+	Name string `json:"name"`
+
+	// Speed Скорость
+	Speed int `json:"speed"`
+
+	// Transport Вид транспорта курьера
+	Transport Transport `json:"transport"`
+}
+
+// NewOrder defines model for NewOrder.
+type NewOrder struct {
+	// Id Идентификатор заказа, по умолчанию генерируется
+	Id *openapi_types.UUID `json:"id,omitempty"`
+
+	// Street Улица доставки
+	Street string `json:"street"`
+
+	// Volume Объем заказа
+	Volume int `json:"volume"`
+}
+
+// NewTrafficZone defines model for NewTrafficZone.
+type NewTrafficZone struct {
+	From Location `json:"from"`
+
+	// Multipliers Множители стоимости клетки по видам транспорта
+	Multipliers TrafficMultipliers `json:"multipliers"`
+	To          Location           `json:"to"`
+}
+
+// Order defines model for Order.
+type Order struct {
+	// Id Идентификатор
+	Id       openapi_types.UUID `json:"id"`
+	Location Location           `json:"location"`
+}
+
+// TrafficMultipliers Множители стоимости клетки по видам транспорта
+type TrafficMultipliers map[string]float64
+
+// TrafficZone defines model for TrafficZone.
+type TrafficZone struct {
+	From Location `json:"from"`
+
+	// Multipliers Множители стоимости клетки по видам транспорта
+	Multipliers TrafficMultipliers `json:"multipliers"`
+
+	// Name Имя
+	Name string   `json:"name"`
+	To   Location `json:"to"`
+}
+
+// Transport Вид транспорта курьера
+type Transport string
+
+// CreateCourierJSONRequestBody defines body for CreateCourier for application/json ContentType.
+type CreateCourierJSONRequestBody = NewCourier
+
+// CreateOrderJSONRequestBody defines body for CreateOrder for application/json ContentType.
+type CreateOrderJSONRequestBody = NewOrder
+
+// SetTrafficZoneJSONRequestBody defines body for SetTrafficZone for application/json ContentType.
+type SetTrafficZoneJSONRequestBody = NewTrafficZone
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Получить всех курьеров
+	// (GET /api/v1/couriers)
+	GetCouriers(ctx echo.Context) error
+	// Добавить курьера
+	// (POST /api/v1/couriers)
+	CreateCourier(ctx echo.Context) error
+	// Создать заказ
+	// (POST /api/v1/orders)
+	CreateOrder(ctx echo.Context) error
+	// Получить все незавершенные заказы
+	// (GET /api/v1/orders/active)
+	GetOrders(ctx echo.Context) error
+	// Получить районы пробок
+	// (GET /api/v1/traffic-zones)
+	GetTrafficZones(ctx echo.Context) error
+	// Удалить район пробок
+	// (DELETE /api/v1/traffic-zones/{name})
+	RemoveTrafficZone(ctx echo.Context, name string) error
+	// Задать район пробок
+	// (PUT /api/v1/traffic-zones/{name})
+	SetTrafficZone(ctx echo.Context, name string) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetCouriers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCouriers(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetCouriers(ctx)
+	return err
+}
+
+// CreateCourier converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateCourier(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateCourier(ctx)
+	return err
+}
+
+// CreateOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateOrder(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateOrder(ctx)
+	return err
+}
+
+// GetOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrders(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrders(ctx)
+	return err
+}
+
+// GetTrafficZones converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTrafficZones(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTrafficZones(ctx)
+	return err
+}
+
+// RemoveTrafficZone converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveTrafficZone(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RemoveTrafficZone(ctx, name)
+	return err
+}
+
+// SetTrafficZone converts echo context to params.
+func (w *ServerInterfaceWrapper) SetTrafficZone(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SetTrafficZone(ctx, name)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/api/v1/couriers", wrapper.GetCouriers)
+	router.POST(baseURL+"/api/v1/couriers", wrapper.CreateCourier)
+	router.POST(baseURL+"/api/v1/orders", wrapper.CreateOrder)
+	router.GET(baseURL+"/api/v1/orders/active", wrapper.GetOrders)
+	router.GET(baseURL+"/api/v1/traffic-zones", wrapper.GetTrafficZones)
+	router.DELETE(baseURL+"/api/v1/traffic-zones/:name", wrapper.RemoveTrafficZone)
+	router.PUT(baseURL+"/api/v1/traffic-zones/:name", wrapper.SetTrafficZone)
+
+}
+
+type GetCouriersRequestObject struct {
+}
+
+type GetCouriersResponseObject interface {
+	VisitGetCouriersResponse(w http.ResponseWriter) error
+}
+
+type GetCouriers200JSONResponse []Courier
+
+func (response GetCouriers200JSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCouriersdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetCouriersdefaultJSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateCourierRequestObject struct {
+	Body *CreateCourierJSONRequestBody
+}
+
+type CreateCourierResponseObject interface {
+	VisitCreateCourierResponse(w http.ResponseWriter) error
+}
+
+type CreateCourier201Response struct {
+}
+
+func (response CreateCourier201Response) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.WriteHeader(201)
+	return nil
+}
+
+type CreateCourier400JSONResponse Error
+
+func (response CreateCourier400JSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCourierdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateCourierdefaultJSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateOrderRequestObject struct {
+	Body *CreateOrderJSONRequestBody
+}
+
+type CreateOrderResponseObject interface {
+	VisitCreateOrderResponse(w http.ResponseWriter) error
+}
+
+type CreateOrder201Response struct {
+}
+
+func (response CreateOrder201Response) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.WriteHeader(201)
+	return nil
+}
+
+type CreateOrder400JSONResponse Error
+
+func (response CreateOrder400JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrderdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateOrderdefaultJSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrdersRequestObject struct {
+}
+
+type GetOrdersResponseObject interface {
+	VisitGetOrdersResponse(w http.ResponseWriter) error
+}
+
+type GetOrders200JSONResponse []Order
+
+func (response GetOrders200JSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrdersdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetOrdersdefaultJSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTrafficZonesRequestObject struct {
+}
+
+type GetTrafficZonesResponseObject interface {
+	VisitGetTrafficZonesResponse(w http.ResponseWriter) error
+}
+
+type GetTrafficZones200JSONResponse []TrafficZone
+
+func (response GetTrafficZones200JSONResponse) VisitGetTrafficZonesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTrafficZonesdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetTrafficZonesdefaultJSONResponse) VisitGetTrafficZonesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RemoveTrafficZoneRequestObject struct {
+	Name string `json:"name"`
+}
+
+type RemoveTrafficZoneResponseObject interface {
+	VisitRemoveTrafficZoneResponse(w http.ResponseWriter) error
+}
+
+type RemoveTrafficZone204Response struct {
+}
+
+func (response RemoveTrafficZone204Response) VisitRemoveTrafficZoneResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveTrafficZone404JSONResponse Error
+
+func (response RemoveTrafficZone404JSONResponse) VisitRemoveTrafficZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveTrafficZonedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response RemoveTrafficZonedefaultJSONResponse) VisitRemoveTrafficZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetTrafficZoneRequestObject struct {
+	Name string `json:"name"`
+	Body *SetTrafficZoneJSONRequestBody
+}
+
+type SetTrafficZoneResponseObject interface {
+	VisitSetTrafficZoneResponse(w http.ResponseWriter) error
+}
+
+type SetTrafficZone204Response struct {
+}
+
+func (response SetTrafficZone204Response) VisitSetTrafficZoneResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type SetTrafficZone400JSONResponse Error
+
+func (response SetTrafficZone400JSONResponse) VisitSetTrafficZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetTrafficZonedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response SetTrafficZonedefaultJSONResponse) VisitSetTrafficZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Получить всех курьеров
+	// (GET /api/v1/couriers)
+	GetCouriers(ctx context.Context, request GetCouriersRequestObject) (GetCouriersResponseObject, error)
+	// Добавить курьера
+	// (POST /api/v1/couriers)
+	CreateCourier(ctx context.Context, request CreateCourierRequestObject) (CreateCourierResponseObject, error)
+	// Создать заказ
+	// (POST /api/v1/orders)
+	CreateOrder(ctx context.Context, request CreateOrderRequestObject) (CreateOrderResponseObject, error)
+	// Получить все незавершенные заказы
+	// (GET /api/v1/orders/active)
+	GetOrders(ctx context.Context, request GetOrdersRequestObject) (GetOrdersResponseObject, error)
+	// Получить районы пробок
+	// (GET /api/v1/traffic-zones)
+	GetTrafficZones(ctx context.Context, request GetTrafficZonesRequestObject) (GetTrafficZonesResponseObject, error)
+	// Удалить район пробок
+	// (DELETE /api/v1/traffic-zones/{name})
+	RemoveTrafficZone(ctx context.Context, request RemoveTrafficZoneRequestObject) (RemoveTrafficZoneResponseObject, error)
+	// Задать район пробок
+	// (PUT /api/v1/traffic-zones/{name})
+	SetTrafficZone(ctx context.Context, request SetTrafficZoneRequestObject) (SetTrafficZoneResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetCouriers operation middleware
+func (sh *strictHandler) GetCouriers(ctx echo.Context) error {
+	var request GetCouriersRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCouriers(ctx.Request().Context(), request.(GetCouriersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCouriers")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetCouriersResponseObject); ok {
+		return validResponse.VisitGetCouriersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateCourier operation middleware
+func (sh *strictHandler) CreateCourier(ctx echo.Context) error {
+	var request CreateCourierRequestObject
+
+	var body CreateCourierJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCourier(ctx.Request().Context(), request.(CreateCourierRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCourier")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateCourierResponseObject); ok {
+		return validResponse.VisitCreateCourierResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateOrder operation middleware
+func (sh *strictHandler) CreateOrder(ctx echo.Context) error {
+	var request CreateOrderRequestObject
+
+	var body CreateOrderJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateOrder(ctx.Request().Context(), request.(CreateOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateOrder")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateOrderResponseObject); ok {
+		return validResponse.VisitCreateOrderResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetOrders operation middleware
+func (sh *strictHandler) GetOrders(ctx echo.Context) error {
+	var request GetOrdersRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrders(ctx.Request().Context(), request.(GetOrdersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrders")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetOrdersResponseObject); ok {
+		return validResponse.VisitGetOrdersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetTrafficZones operation middleware
+func (sh *strictHandler) GetTrafficZones(ctx echo.Context) error {
+	var request GetTrafficZonesRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTrafficZones(ctx.Request().Context(), request.(GetTrafficZonesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTrafficZones")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTrafficZonesResponseObject); ok {
+		return validResponse.VisitGetTrafficZonesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RemoveTrafficZone operation middleware
+func (sh *strictHandler) RemoveTrafficZone(ctx echo.Context, name string) error {
+	var request RemoveTrafficZoneRequestObject
+
+	request.Name = name
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveTrafficZone(ctx.Request().Context(), request.(RemoveTrafficZoneRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveTrafficZone")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RemoveTrafficZoneResponseObject); ok {
+		return validResponse.VisitRemoveTrafficZoneResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SetTrafficZone operation middleware
+func (sh *strictHandler) SetTrafficZone(ctx echo.Context, name string) error {
+	var request SetTrafficZoneRequestObject
+
+	request.Name = name
+
+	var body SetTrafficZoneJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SetTrafficZone(ctx.Request().Context(), request.(SetTrafficZoneRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetTrafficZone")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SetTrafficZoneResponseObject); ok {
+		return validResponse.VisitSetTrafficZoneResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xY324Txxd+ldX8fpdbHCBXviytqkq0VIWLtoiLye44DNrd2c7OBtxoJcdpAZWISFWl",
+	"VlVFS3kBJ8RlcZLlFc68UXVmbGdtjxMHEoQQN/b+nfOd833nz+w6CUScioQlKiPNdZIFt1lMzeEVkUvO",
+	"JB6mUqRMKs7MDR7ib8iyQPJUcZGQJoHfYQ/6cKi7UOofoYQB9HQXKt0hPmkJGVNFmiTPeUh8otopI02S",
+	"KcmTVVL4JBIBtQutk/9L1iJN8r/GEbDGEFXj6ui5wicJjZkTx4HedtlQkiZZKqQ6yciN8YNF4RPJvs+5",
+	"ZCFp3iQGvLFbX64G/9bYrli5wwKFdj+VUjiCGIjQBf8PqGDPg0o/hBJ2YABlPX48UZcvHTnHE8VWmUQr",
+	"Mcsyuupa8R/ow0Bv6O70qlMRmvLV4Dta1+XZ1Rprk87dm8XxjRN2e/bBbx0PTmG7R/BNF6Qv2d25sj2t",
+	"YLKUMZfSn8IAdQ0VBlVvOf06A7E5dGYRzfH7mgzfPFk9eAE9PMV/34NXUHl6Ew6ggn39AHpwCKV+7MFz",
+	"fB36ugOl7uhN6Ouu3tDbda3Oy/VMScaUA9Qz2IdS34eeB3s2uNCDXbdYfbImotzJ5xPY0T9DHw4mfDlZ",
+	"VENc45XnhPmGpK0WD74TCZsNdkuK+DQ1LM4jxdOIM5ktIBS0+0XtDdSZWNzclL8Gq1lhEofL7zPR1vk0",
+	"AleJPrYeOwLZXCc0DDm+QqOvJhkdIQ5FvhKxI8xJHq/YXJ/y/084hAr+hVJ3oY+S9oyUKygxjcxx6cEA",
+	"9jFpUN02y2AXStiDHhx4uqs7mGp6A+/oDiYCme/JOyLFU/fj15fusDSeRsE36gV5CuIvGHln1D0Y6E3d",
+	"0Vum1CEJLMljhJCykKEvNCE+WeFBOzDiCKismR+31sInPGkJV7XSXdiFvqmtfezRL2z9Kw0MLKsPbJnF",
+	"G/q+rb6O8shVhBav36Wrq0x6n7CIrzHZxnLGZGaNXbywdGEJgyFSltCUkya5bC75JKXqtuG9QVPeWLvY",
+	"CGwXNddWneX6L6jgBeyazrBtsb8yJ5v6AYpfb3mwqzegr3+aDGMFu8RgkIbdz0PSJJ8xdWVkEbnOUpFk",
+	"VsiXlpbsvJQolhggNE0jbqXRuJPZYmE1g0dcsfhECQ+NkWLMFZWSti1V031pSMVDONSP4CWOUZazrs3+",
+	"Fs0jdSqIxyGz46ILx5Px9NYzGZHlcUxle8TFYoEvfJKKbEE+96CCHaOy4bLT2TBJ4hXJqGKj0NqUZZn6",
+	"WITtMwtPbcArJsuCkjkrZrRz0TVoHEvo8tLSmaFdiEws/T3Yt+UfUxzKd0ZYvx6vAHx6VDEEzggm6gsL",
+	"TG+YS+i2WXw8r3l6w9P3sX3qLf3Yw05qG6dV8XAO3Z6jQDutnJv+7PIf1Hf+6ns6Rx4O3TVooPgaO4N2",
+	"5eHGxthCUjr6odnqIFf9GgT9yNXDrtkceBsdbKjC97l/LcxEXQ7KDqcf/SASdhbTi2emwpdQoWEPXpkK",
+	"tAMVDFwCqI3kb0cGNYPvqxjmEzCX98Y6bhEKy3vEFFusHW2aDdj+jN3jaf+axWKN1YmYIX75NfrA8ltg",
+	"4e8jDw9tvuG52b+/M3J4thApONdSSWOmzBBy070Prb1u5leOt3DvM/qu27R/053drzk4vbe75ZM0f615",
+	"Z44vHpTms4EpcQem5g0LUh+eQzUcjMyXlQoOZuR4faIKnd8YNFF5FhmGlj8MQ2+SB79B7yTl2CUzJtdG",
+	"SZDLiDRJgxS3iv8GACVJsSLqGQAA",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
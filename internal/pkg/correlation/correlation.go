@@ -0,0 +1,73 @@
+package correlation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Заголовки, в которых идентификаторы передаются по HTTP и Kafka
+const (
+	RequestIDHeader     = "X-Request-ID"
+	CorrelationIDHeader = "X-Correlation-ID"
+	CausationIDHeader   = "X-Causation-ID"
+	MessageIDHeader     = "X-Message-ID"
+)
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	correlationIDKey
+	causationIDKey
+)
+
+// WithRequestID сохраняет идентификатор входящего HTTP-запроса
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return withValue(ctx, requestIDKey, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	return value(ctx, requestIDKey)
+}
+
+// WithCorrelationID сохраняет идентификатор цепочки: он одинаков у запроса,
+// созданных им событий и сообщений, отправленных по этим событиям
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return withValue(ctx, correlationIDKey, correlationID)
+}
+
+func CorrelationID(ctx context.Context) string {
+	return value(ctx, correlationIDKey)
+}
+
+// WithCausationID сохраняет идентификатор сообщения или события, которое
+// непосредственно вызвало текущую обработку
+func WithCausationID(ctx context.Context, causationID string) context.Context {
+	return withValue(ctx, causationIDKey, causationID)
+}
+
+func CausationID(ctx context.Context) string {
+	return value(ctx, causationIDKey)
+}
+
+// EnsureCorrelationID начинает новую цепочку, если в контексте ее еще нет:
+// например, для тика фоновой задачи
+func EnsureCorrelationID(ctx context.Context) context.Context {
+	if CorrelationID(ctx) != "" {
+		return ctx
+	}
+	return WithCorrelationID(ctx, uuid.NewString())
+}
+
+func withValue(ctx context.Context, key contextKey, value string) context.Context {
+	if value == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, key, value)
+}
+
+func value(ctx context.Context, key contextKey) string {
+	value, _ := ctx.Value(key).(string)
+	return value
+}
@@ -0,0 +1,25 @@
+package correlation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsureCorrelationID(t *testing.T) {
+	ctx := EnsureCorrelationID(context.Background())
+	correlationID := CorrelationID(ctx)
+	assert.NotEmpty(t, correlationID)
+
+	assert.Equal(t, correlationID, CorrelationID(EnsureCorrelationID(ctx)))
+}
+
+func TestWithValue_IgnoresEmptyIds(t *testing.T) {
+	ctx := WithCorrelationID(context.Background(), "correlation-1")
+	ctx = WithCorrelationID(ctx, "")
+
+	assert.Equal(t, "correlation-1", CorrelationID(ctx))
+	assert.Empty(t, CausationID(ctx))
+	assert.Empty(t, RequestID(ctx))
+}
@@ -2,6 +2,7 @@ package ddd
 
 type BaseAggregate[ID comparable] struct {
 	*BaseEntity[ID]
+	version      int
 	domainEvents []DomainEvent
 }
 
@@ -12,6 +13,30 @@ func NewBaseAggregate[ID comparable](id ID) *BaseAggregate[ID] {
 	}
 }
 
+func RestoreBaseAggregate[ID comparable](id ID, version int) *BaseAggregate[ID] {
+	return &BaseAggregate[ID]{
+		BaseEntity:   NewBaseEntity[ID](id),
+		version:      version,
+		domainEvents: make([]DomainEvent, 0),
+	}
+}
+
+// Version возвращает номер версии, под которым агрегат был сохранен последний
+// раз. Новый, еще не сохраненный агрегат имеет версию 0.
+func (a *BaseAggregate[ID]) Version() int {
+	return a.version
+}
+
+func (a *BaseAggregate[ID]) IncrementVersion() {
+	a.version++
+}
+
+// RestoreVersion возвращает версию, под которой агрегат был прочитан, если
+// транзакция с его сохранением откатилась
+func (a *BaseAggregate[ID]) RestoreVersion(version int) {
+	a.version = version
+}
+
 func (a *BaseAggregate[ID]) ClearDomainEvents() {
 	a.domainEvents = []DomainEvent{}
 }
@@ -0,0 +1,67 @@
+package ddd
+
+import "context"
+
+// CommandHandler и QueryHandler — общая форма обработчиков use cases. Им
+// соответствуют интерфейсы конкретных обработчиков в слое приложения, поэтому
+// декораторы работают с любым из них.
+type CommandHandler[C any] interface {
+	Handle(ctx context.Context, command C) error
+}
+
+type QueryHandler[Q any, R any] interface {
+	Handle(ctx context.Context, query Q) (R, error)
+}
+
+// HandlerDecorator оборачивает вызов обработчика с именем name: логирование,
+// трассировка и т.п. Он не знает типов команды и результата.
+type HandlerDecorator func(ctx context.Context, name string, next func(ctx context.Context) error) error
+
+type decoratedCommandHandler[C any] struct {
+	name       string
+	next       CommandHandler[C]
+	decorators []HandlerDecorator
+}
+
+// DecorateCommandHandler применяет декораторы к обработчику команды. Первый
+// декоратор оказывается внешним.
+func DecorateCommandHandler[C any](name string, next CommandHandler[C], decorators ...HandlerDecorator) CommandHandler[C] {
+	return &decoratedCommandHandler[C]{name: name, next: next, decorators: decorators}
+}
+
+func (h *decoratedCommandHandler[C]) Handle(ctx context.Context, command C) error {
+	return decorate(ctx, h.name, h.decorators, func(ctx context.Context) error {
+		return h.next.Handle(ctx, command)
+	})
+}
+
+type decoratedQueryHandler[Q any, R any] struct {
+	name       string
+	next       QueryHandler[Q, R]
+	decorators []HandlerDecorator
+}
+
+// DecorateQueryHandler — то же для обработчика запроса
+func DecorateQueryHandler[Q any, R any](name string, next QueryHandler[Q, R], decorators ...HandlerDecorator) QueryHandler[Q, R] {
+	return &decoratedQueryHandler[Q, R]{name: name, next: next, decorators: decorators}
+}
+
+func (h *decoratedQueryHandler[Q, R]) Handle(ctx context.Context, query Q) (R, error) {
+	var response R
+	err := decorate(ctx, h.name, h.decorators, func(ctx context.Context) error {
+		var err error
+		response, err = h.next.Handle(ctx, query)
+		return err
+	})
+	return response, err
+}
+
+func decorate(ctx context.Context, name string, decorators []HandlerDecorator, call func(ctx context.Context) error) error {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		decorator, next := decorators[i], call
+		call = func(ctx context.Context) error {
+			return decorator(ctx, name, next)
+		}
+	}
+	return call(ctx)
+}
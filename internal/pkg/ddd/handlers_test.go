@@ -0,0 +1,70 @@
+package ddd
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubQueryHandler struct {
+	err error
+}
+
+func (h stubQueryHandler) Handle(_ context.Context, query int) (string, error) {
+	if h.err != nil {
+		return "", h.err
+	}
+	return "query " + strconv.Itoa(query), nil
+}
+
+type commandHandlerFunc func(ctx context.Context, command string) error
+
+func (f commandHandlerFunc) Handle(ctx context.Context, command string) error {
+	return f(ctx, command)
+}
+
+// recordingDecorator пишет в calls порядок входа и выхода
+func recordingDecorator(label string, calls *[]string) HandlerDecorator {
+	return func(ctx context.Context, name string, next func(ctx context.Context) error) error {
+		*calls = append(*calls, label+" "+name)
+		err := next(ctx)
+		*calls = append(*calls, label+" done")
+		return err
+	}
+}
+
+func TestDecorateCommandHandler(t *testing.T) {
+	var calls []string
+	handlerErr := errors.New("db is down")
+	next := commandHandlerFunc(func(_ context.Context, command string) error {
+		calls = append(calls, "handle "+command)
+		return handlerErr
+	})
+
+	handler := DecorateCommandHandler[string]("CreateOrder", next,
+		recordingDecorator("outer", &calls),
+		recordingDecorator("inner", &calls),
+	)
+	err := handler.Handle(context.Background(), "command")
+
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Equal(t, []string{"outer CreateOrder", "inner CreateOrder", "handle command", "inner done", "outer done"}, calls)
+}
+
+func TestDecorateQueryHandler(t *testing.T) {
+	var calls []string
+
+	response, err := DecorateQueryHandler[int, string]("GetOrders", stubQueryHandler{}, recordingDecorator("log", &calls)).
+		Handle(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, "query 7", response)
+	assert.Equal(t, []string{"log GetOrders", "log done"}, calls)
+
+	handlerErr := errors.New("db is down")
+	_, err = DecorateQueryHandler[int, string]("GetOrders", stubQueryHandler{err: handlerErr}).Handle(context.Background(), 7)
+	assert.ErrorIs(t, err, handlerErr)
+}
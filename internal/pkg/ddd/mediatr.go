@@ -1,6 +1,9 @@
 package ddd
 
-import "context"
+import (
+	"context"
+	"delivery/internal/pkg/correlation"
+)
 
 type EventHandler interface {
 	Handle(ctx context.Context, event DomainEvent) error
@@ -27,7 +30,10 @@ func (e *mediatr) Subscribe(handler EventHandler, events ...DomainEvent) {
 	}
 }
 
+// Publish передает обработчикам контекст, в котором причиной (causation)
+// указано само событие, а correlation ID сохраняется от исходного запроса
 func (e *mediatr) Publish(ctx context.Context, event DomainEvent) error {
+	ctx = correlation.WithCausationID(ctx, event.GetID().String())
 	for _, handler := range e.handlers[event.GetName()] {
 		err := handler.Handle(ctx, event)
 		if err != nil {
@@ -0,0 +1,164 @@
+package health
+
+import (
+	"context"
+	"delivery/internal/pkg/errs"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+	// StatusDegraded — недоступна необязательная зависимость, но сервис
+	// продолжает обслуживать запросы
+	StatusDegraded Status = "degraded"
+)
+
+// Checker проверяет одну зависимость. Details попадают в ответ как есть,
+// ошибка означает, что зависимость недоступна.
+type Checker interface {
+	Check(ctx context.Context) (map[string]any, error)
+}
+
+type CheckerFunc func(ctx context.Context) (map[string]any, error)
+
+func (f CheckerFunc) Check(ctx context.Context) (map[string]any, error) {
+	return f(ctx)
+}
+
+type CheckResult struct {
+	Status    Status         `json:"status"`
+	LatencyMs float64        `json:"latencyMs"`
+	Details   map[string]any `json:"details,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	Optional  bool           `json:"optional,omitempty"`
+}
+
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Registry хранит проверки зависимостей и выполняет их параллельно, ограничивая
+// каждую таймаутом: зависшая зависимость считается недоступной. Отказ
+// необязательной проверки переводит отчет только в StatusDegraded.
+type Registry struct {
+	timeout  time.Duration
+	names    []string
+	checkers map[string]registeredChecker
+}
+
+type registeredChecker struct {
+	checker  Checker
+	optional bool
+}
+
+func NewRegistry(timeout time.Duration) (*Registry, error) {
+	if timeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("timeout")
+	}
+
+	return &Registry{
+		timeout:  timeout,
+		checkers: make(map[string]registeredChecker),
+	}, nil
+}
+
+func (r *Registry) Register(name string, checker Checker) error {
+	return r.register(name, checker, false)
+}
+
+// RegisterOptional добавляет проверку, которая видна в отчете, но не делает
+// сервис неготовым: например, брокер, отказ которого поглощает outbox
+func (r *Registry) RegisterOptional(name string, checker Checker) error {
+	return r.register(name, checker, true)
+}
+
+func (r *Registry) register(name string, checker Checker, optional bool) error {
+	if name == "" {
+		return errs.NewValueIsRequiredError("name")
+	}
+	if checker == nil {
+		return errs.NewValueIsRequiredError("checker")
+	}
+	if _, ok := r.checkers[name]; ok {
+		return errs.NewValueIsInvalidErrorWithCause("name", fmt.Errorf("checker %s is already registered", name))
+	}
+
+	r.names = append(r.names, name)
+	r.checkers[name] = registeredChecker{checker: checker, optional: optional}
+	return nil
+}
+
+func (r *Registry) Check(ctx context.Context) Report {
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(r.names)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, name := range r.names {
+		wg.Add(1)
+		go func(name string, registered registeredChecker) {
+			defer wg.Done()
+			result := r.run(ctx, registered.checker)
+			result.Optional = registered.optional
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			switch {
+			case result.Status == StatusUp:
+			case registered.optional:
+				if report.Status == StatusUp {
+					report.Status = StatusDegraded
+				}
+			default:
+				report.Status = StatusDown
+			}
+		}(name, r.checkers[name])
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, checker Checker) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	type outcome struct {
+		details map[string]any
+		err     error
+	}
+	done := make(chan outcome, 1)
+
+	start := time.Now()
+	go func() {
+		details, err := checker.Check(ctx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	var result outcome
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result = outcome{err: fmt.Errorf("check timed out: %w", ctx.Err())}
+	}
+
+	checkResult := CheckResult{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		Details:   result.details,
+	}
+	if result.err != nil {
+		checkResult.Status = StatusDown
+		checkResult.Error = result.err.Error()
+	}
+	return checkResult
+}
@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func up(details map[string]any) Checker {
+	return CheckerFunc(func(context.Context) (map[string]any, error) {
+		return details, nil
+	})
+}
+
+func down(err error) Checker {
+	return CheckerFunc(func(context.Context) (map[string]any, error) {
+		return nil, err
+	})
+}
+
+func TestNewRegistry(t *testing.T) {
+	_, err := NewRegistry(0)
+	assert.Error(t, err)
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry, err := NewRegistry(time.Second)
+	require.NoError(t, err)
+
+	assert.Error(t, registry.Register("", up(nil)))
+	assert.Error(t, registry.Register("postgres", nil))
+	require.NoError(t, registry.Register("postgres", up(nil)))
+	assert.Error(t, registry.Register("postgres", up(nil)))
+	assert.Error(t, registry.RegisterOptional("postgres", up(nil)))
+	assert.Error(t, registry.RegisterOptional("kafka", nil))
+}
+
+func TestRegistry_Check_AllUp(t *testing.T) {
+	registry, err := NewRegistry(time.Second)
+	require.NoError(t, err)
+	require.NoError(t, registry.Register("postgres", up(map[string]any{"openConnections": 2})))
+	require.NoError(t, registry.Register("kafka", up(nil)))
+
+	report := registry.Check(context.Background())
+
+	assert.Equal(t, StatusUp, report.Status)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, StatusUp, report.Checks["postgres"].Status)
+	assert.Equal(t, 2, report.Checks["postgres"].Details["openConnections"])
+	assert.Empty(t, report.Checks["kafka"].Error)
+}
+
+func TestRegistry_Check_OneDown(t *testing.T) {
+	registry, err := NewRegistry(time.Second)
+	require.NoError(t, err)
+	require.NoError(t, registry.Register("postgres", down(errors.New("connection refused"))))
+	require.NoError(t, registry.Register("kafka", up(nil)))
+
+	report := registry.Check(context.Background())
+
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, StatusDown, report.Checks["postgres"].Status)
+	assert.Equal(t, "connection refused", report.Checks["postgres"].Error)
+	assert.Equal(t, StatusUp, report.Checks["kafka"].Status)
+}
+
+func TestRegistry_Check_OptionalDown(t *testing.T) {
+	registry, err := NewRegistry(time.Second)
+	require.NoError(t, err)
+	require.NoError(t, registry.Register("postgres", up(nil)))
+	require.NoError(t, registry.RegisterOptional("kafka", down(errors.New("no brokers"))))
+
+	report := registry.Check(context.Background())
+
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, StatusDown, report.Checks["kafka"].Status)
+	assert.True(t, report.Checks["kafka"].Optional)
+	assert.False(t, report.Checks["postgres"].Optional)
+
+	require.NoError(t, registry.Register("geo", down(errors.New("unavailable"))))
+	assert.Equal(t, StatusDown, registry.Check(context.Background()).Status)
+}
+
+func TestRegistry_Check_Timeout(t *testing.T) {
+	registry, err := NewRegistry(20 * time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, registry.Register("geo", CheckerFunc(func(context.Context) (map[string]any, error) {
+		time.Sleep(time.Second)
+		return nil, nil
+	})))
+
+	start := time.Now()
+	report := registry.Check(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Contains(t, report.Checks["geo"].Error, "timed out")
+	assert.Positive(t, report.Checks["geo"].LatencyMs)
+}
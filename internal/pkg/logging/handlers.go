@@ -0,0 +1,28 @@
+package logging
+
+import (
+	"context"
+	"delivery/internal/pkg/ddd"
+	"log/slog"
+	"time"
+)
+
+// HandlerDecorator пишет в debug каждый вызов обработчика use case вместе с
+// идентификаторами из контекста. Ошибки на уровне error пишет вызывающая
+// сторона (HTTP, job, consumer): она знает, ожидаема ли ошибка.
+func HandlerDecorator(logger *slog.Logger) ddd.HandlerDecorator {
+	return func(ctx context.Context, name string, next func(ctx context.Context) error) error {
+		start := time.Now()
+		err := next(ctx)
+
+		attrs := []slog.Attr{
+			slog.String("handler", name),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "use case handled", attrs...)
+		return err
+	}
+}
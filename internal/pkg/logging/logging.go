@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"context"
+	"delivery/internal/pkg/correlation"
+	"delivery/internal/pkg/errs"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Форматы вывода
+const (
+	FormatJson = "json"
+	FormatText = "text"
+)
+
+var (
+	Formats = []string{FormatJson, FormatText}
+	Levels  = []string{"debug", "info", "warn", "error"}
+)
+
+type Config struct {
+	Level  string
+	Format string
+}
+
+// New создает логгер, который дописывает к каждой записи идентификаторы из
+// контекста: request_id, correlation_id, causation_id и trace_id. Поэтому
+// писать нужно методами *Context (InfoContext, ErrorContext).
+func New(w io.Writer, config Config) (*slog.Logger, error) {
+	if w == nil {
+		return nil, errs.NewValueIsRequiredError("w")
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToLower(config.Level))); err != nil {
+		return nil, errs.NewValueIsInvalidErrorWithCause("level", err)
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch config.Format {
+	case FormatJson:
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, errs.NewValueIsInvalidError("format")
+	}
+
+	return slog.New(&contextHandler{next: handler}), nil
+}
+
+// Discard — логгер для тестов и необязательных зависимостей
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+type contextHandler struct {
+	next slog.Handler
+}
+
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := correlation.RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if correlationID := correlation.CorrelationID(ctx); correlationID != "" {
+		record.AddAttrs(slog.String("correlation_id", correlationID))
+	}
+	if causationID := correlation.CausationID(ctx); causationID != "" {
+		record.AddAttrs(slog.String("causation_id", causationID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{next: h.next.WithGroup(name)}
+}
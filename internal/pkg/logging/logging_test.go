@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"delivery/internal/pkg/correlation"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_Validation(t *testing.T) {
+	_, err := New(nil, Config{Level: "info", Format: FormatJson})
+	assert.Error(t, err)
+
+	_, err = New(&bytes.Buffer{}, Config{Level: "verbose", Format: FormatJson})
+	assert.Error(t, err)
+
+	_, err = New(&bytes.Buffer{}, Config{Level: "info", Format: "xml"})
+	assert.Error(t, err)
+}
+
+func TestNew_WritesIdsFromContext(t *testing.T) {
+	var output bytes.Buffer
+	logger, err := New(&output, Config{Level: "info", Format: FormatJson})
+	require.NoError(t, err)
+
+	ctx := correlation.WithRequestID(context.Background(), "request-1")
+	ctx = correlation.WithCorrelationID(ctx, "correlation-1")
+	ctx = correlation.WithCausationID(ctx, "event-1")
+	logger.InfoContext(ctx, "order created")
+	logger.DebugContext(ctx, "filtered by level")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	assert.Equal(t, "order created", record["msg"])
+	assert.Equal(t, "request-1", record["request_id"])
+	assert.Equal(t, "correlation-1", record["correlation_id"])
+	assert.Equal(t, "event-1", record["causation_id"])
+}
+
+func TestHandlerDecorator(t *testing.T) {
+	var output bytes.Buffer
+	logger, err := New(&output, Config{Level: "debug", Format: FormatJson})
+	require.NoError(t, err)
+
+	ctx := correlation.WithCorrelationID(context.Background(), "correlation-1")
+	require.NoError(t, HandlerDecorator(logger)(ctx, "CreateOrderCommandHandler", func(context.Context) error { return nil }))
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	assert.Equal(t, "CreateOrderCommandHandler", record["handler"])
+	assert.Equal(t, "correlation-1", record["correlation_id"])
+}
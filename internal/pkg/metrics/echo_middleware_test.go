@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEchoMiddleware_UsesRouteTemplate(t *testing.T) {
+	e := echo.New()
+	e.Use(EchoMiddleware())
+	e.GET("/api/v1/orders/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.GET("/api/v1/fail", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusConflict)
+	})
+
+	ok := httpRequestsTotal.WithLabelValues("/api/v1/orders/:id", http.MethodGet, "204")
+	conflict := httpRequestsTotal.WithLabelValues("/api/v1/fail", http.MethodGet, "409")
+	okBefore, conflictBefore := testutil.ToFloat64(ok), testutil.ToFloat64(conflict)
+
+	for _, path := range []string{"/api/v1/orders/1", "/api/v1/orders/2", "/api/v1/fail"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, okBefore+2, testutil.ToFloat64(ok))
+	assert.Equal(t, conflictBefore+1, testutil.ToFloat64(conflict))
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "delivery"
+
+// Исходы распределения заказа
+const (
+	DispatchAssigned  = "assigned"
+	DispatchNoCourier = "no_courier"
+	DispatchError     = "error"
+)
+
+// Результаты обработки сообщений Kafka
+const (
+	KafkaResultSuccess      = "success"
+	KafkaResultError        = "error"
+	KafkaResultMalformed    = "malformed"
+	KafkaResultRejected     = "rejected"
+	KafkaResultDeadLettered = "dead_lettered"
+)
+
+// Registry — собственный реестр вместо глобального, чтобы в /metrics попадали
+// только метрики сервиса и рантайма Go
+var Registry = prometheus.NewRegistry()
+
+var (
+	dispatchTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dispatch_total",
+		Help:      "Order dispatch attempts by outcome.",
+	}, []string{"outcome"})
+
+	dispatchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dispatch_duration_seconds",
+		Help:      "Time spent choosing a courier for an order.",
+		Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .5},
+	}, []string{"outcome"})
+
+	kafkaMessagesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_messages_total",
+		Help:      "Kafka messages consumed or produced by result.",
+	}, []string{"topic", "operation", "result"})
+
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		dispatchTotal,
+		dispatchDuration,
+		kafkaMessagesTotal,
+		httpRequestsTotal,
+		httpRequestDuration,
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		Registry: Registry,
+		// Недоступная база не должна прятать остальные метрики
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+func ObserveDispatch(outcome string, duration time.Duration) {
+	dispatchTotal.WithLabelValues(outcome).Inc()
+	dispatchDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+func ObserveKafkaConsumed(topic string, result string) {
+	kafkaMessagesTotal.WithLabelValues(topic, "consume", result).Inc()
+}
+
+func ObserveKafkaProduced(topic string, err error) {
+	result := KafkaResultSuccess
+	if err != nil {
+		result = KafkaResultError
+	}
+	kafkaMessagesTotal.WithLabelValues(topic, "produce", result).Inc()
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/outbox"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const collectTimeout = 2 * time.Second
+
+// StateReader читает текущее состояние заказов и курьеров
+type StateReader interface {
+	CountOrdersByStatus(ctx context.Context) (map[string]int64, error)
+	CountStoragePlaces(ctx context.Context) (occupied int64, free int64, err error)
+}
+
+var _ prometheus.Collector = &StateCollector{}
+
+// StateCollector снимает метрики состояния в момент опроса /metrics, а не
+// хранит счетчики в памяти: после рестарта значения остаются верными, и
+// несколько экземпляров сервиса показывают одно и то же.
+type StateCollector struct {
+	stateReader   StateReader
+	backlogReader outbox.BacklogReader
+	clock         clock.Clock
+
+	orders            *prometheus.Desc
+	storagePlaces     *prometheus.Desc
+	utilisation       *prometheus.Desc
+	outboxPending     *prometheus.Desc
+	outboxOldestAge   *prometheus.Desc
+	outboxQuarantined *prometheus.Desc
+}
+
+func NewStateCollector(stateReader StateReader, backlogReader outbox.BacklogReader, clk clock.Clock) (*StateCollector, error) {
+	if stateReader == nil {
+		return nil, errs.NewValueIsRequiredError("stateReader")
+	}
+	if backlogReader == nil {
+		return nil, errs.NewValueIsRequiredError("backlogReader")
+	}
+	if clk == nil {
+		return nil, errs.NewValueIsRequiredError("clk")
+	}
+
+	return &StateCollector{
+		stateReader:   stateReader,
+		backlogReader: backlogReader,
+		clock:         clk,
+
+		orders: prometheus.NewDesc(namespace+"_orders",
+			"Orders by status.", []string{"status"}, nil),
+		storagePlaces: prometheus.NewDesc(namespace+"_storage_places",
+			"Courier storage places by state.", []string{"state"}, nil),
+		utilisation: prometheus.NewDesc(namespace+"_courier_utilisation_ratio",
+			"Share of occupied courier storage places.", nil, nil),
+		outboxPending: prometheus.NewDesc(namespace+"_outbox_pending_messages",
+			"Outbox messages waiting for publication.", nil, nil),
+		outboxOldestAge: prometheus.NewDesc(namespace+"_outbox_oldest_pending_age_seconds",
+			"Age of the oldest outbox message waiting for publication.", nil, nil),
+		outboxQuarantined: prometheus.NewDesc(namespace+"_outbox_quarantined_messages",
+			"Outbox messages quarantined after exhausting publication attempts.", nil, nil),
+	}, nil
+}
+
+func (c *StateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.orders
+	ch <- c.storagePlaces
+	ch <- c.utilisation
+	ch <- c.outboxPending
+	ch <- c.outboxOldestAge
+	ch <- c.outboxQuarantined
+}
+
+func (c *StateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	if counts, err := c.stateReader.CountOrdersByStatus(ctx); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.orders, err)
+	} else {
+		for status, count := range counts {
+			ch <- prometheus.MustNewConstMetric(c.orders, prometheus.GaugeValue, float64(count), status)
+		}
+	}
+
+	if occupied, free, err := c.stateReader.CountStoragePlaces(ctx); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.storagePlaces, err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(c.storagePlaces, prometheus.GaugeValue, float64(occupied), "occupied")
+		ch <- prometheus.MustNewConstMetric(c.storagePlaces, prometheus.GaugeValue, float64(free), "free")
+
+		utilisation := 0.0
+		if total := occupied + free; total > 0 {
+			utilisation = float64(occupied) / float64(total)
+		}
+		ch <- prometheus.MustNewConstMetric(c.utilisation, prometheus.GaugeValue, utilisation)
+	}
+
+	if backlog, err := c.backlogReader.GetBacklog(ctx); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.outboxPending, err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(c.outboxPending, prometheus.GaugeValue, float64(backlog.Count))
+		ch <- prometheus.MustNewConstMetric(c.outboxOldestAge, prometheus.GaugeValue,
+			backlog.Age(c.clock.Now().UTC()).Seconds())
+		ch <- prometheus.MustNewConstMetric(c.outboxQuarantined, prometheus.GaugeValue, float64(backlog.Quarantined))
+	}
+}
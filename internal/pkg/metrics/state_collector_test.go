@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/outbox"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStateReader struct {
+	orders   map[string]int64
+	occupied int64
+	free     int64
+	err      error
+}
+
+func (r fakeStateReader) CountOrdersByStatus(context.Context) (map[string]int64, error) {
+	return r.orders, r.err
+}
+
+func (r fakeStateReader) CountStoragePlaces(context.Context) (int64, int64, error) {
+	return r.occupied, r.free, r.err
+}
+
+type fakeBacklogReader struct {
+	backlog outbox.Backlog
+}
+
+func (r fakeBacklogReader) GetBacklog(context.Context) (outbox.Backlog, error) {
+	return r.backlog, nil
+}
+
+func TestStateCollector_Collect(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	oldest := clk.Now().Add(-time.Hour)
+	collector, err := NewStateCollector(
+		fakeStateReader{orders: map[string]int64{"Created": 3, "Assigned": 1}, occupied: 1, free: 3},
+		fakeBacklogReader{backlog: outbox.Backlog{Count: 2, OldestOccurredAtUtc: &oldest, Quarantined: 1}},
+		clk,
+	)
+	require.NoError(t, err)
+
+	expected := `
+# HELP delivery_courier_utilisation_ratio Share of occupied courier storage places.
+# TYPE delivery_courier_utilisation_ratio gauge
+delivery_courier_utilisation_ratio 0.25
+# HELP delivery_orders Orders by status.
+# TYPE delivery_orders gauge
+delivery_orders{status="Assigned"} 1
+delivery_orders{status="Created"} 3
+# HELP delivery_outbox_oldest_pending_age_seconds Age of the oldest outbox message waiting for publication.
+# TYPE delivery_outbox_oldest_pending_age_seconds gauge
+delivery_outbox_oldest_pending_age_seconds 3600
+# HELP delivery_outbox_pending_messages Outbox messages waiting for publication.
+# TYPE delivery_outbox_pending_messages gauge
+delivery_outbox_pending_messages 2
+# HELP delivery_outbox_quarantined_messages Outbox messages quarantined after exhausting publication attempts.
+# TYPE delivery_outbox_quarantined_messages gauge
+delivery_outbox_quarantined_messages 1
+# HELP delivery_storage_places Courier storage places by state.
+# TYPE delivery_storage_places gauge
+delivery_storage_places{state="free"} 3
+delivery_storage_places{state="occupied"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"delivery_orders",
+		"delivery_storage_places",
+		"delivery_courier_utilisation_ratio",
+		"delivery_outbox_pending_messages",
+		"delivery_outbox_oldest_pending_age_seconds",
+		"delivery_outbox_quarantined_messages",
+	))
+}
+
+func TestStateCollector_ReaderErrorKeepsOtherMetrics(t *testing.T) {
+	collector, err := NewStateCollector(
+		fakeStateReader{err: errors.New("db is down")},
+		fakeBacklogReader{backlog: outbox.Backlog{Count: 0}},
+		clock.System(),
+	)
+	require.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	families, err := registry.Gather()
+	assert.ErrorContains(t, err, "db is down")
+
+	var names []string
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.Contains(t, names, "delivery_outbox_pending_messages")
+}
+
+func TestNewStateCollector_Validation(t *testing.T) {
+	_, err := NewStateCollector(nil, fakeBacklogReader{}, clock.System())
+	assert.Error(t, err)
+
+	_, err = NewStateCollector(fakeStateReader{}, nil, clock.System())
+	assert.Error(t, err)
+
+	_, err = NewStateCollector(fakeStateReader{}, fakeBacklogReader{}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package outbox
+
+import (
+	"context"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/health"
+	"fmt"
+	"time"
+)
+
+// Backlog — сообщения, которые еще не опубликованы. Сообщения в карантине
+// relay не публикует, поэтому они считаются отдельно и не влияют на возраст.
+type Backlog struct {
+	Count               int64
+	OldestOccurredAtUtc *time.Time
+	Quarantined         int64
+}
+
+// Age возвращает, сколько ждет самое старое неопубликованное сообщение
+func (b Backlog) Age(now time.Time) time.Duration {
+	if b.OldestOccurredAtUtc == nil {
+		return 0
+	}
+	return now.Sub(*b.OldestOccurredAtUtc)
+}
+
+type BacklogReader interface {
+	GetBacklog(ctx context.Context) (Backlog, error)
+}
+
+var _ health.Checker = &BacklogChecker{}
+
+// BacklogChecker считает outbox неисправным, если самое старое сообщение ждет
+// публикации дольше maxAge: значит, relay остановился или брокер недоступен.
+type BacklogChecker struct {
+	reader BacklogReader
+	maxAge time.Duration
+	clk    clock.Clock
+}
+
+func NewBacklogChecker(reader BacklogReader, maxAge time.Duration, clk clock.Clock) (*BacklogChecker, error) {
+	if reader == nil {
+		return nil, errs.NewValueIsRequiredError("reader")
+	}
+	if maxAge <= 0 {
+		return nil, errs.NewValueIsInvalidError("maxAge")
+	}
+	if clk == nil {
+		return nil, errs.NewValueIsRequiredError("clk")
+	}
+
+	return &BacklogChecker{
+		reader: reader,
+		maxAge: maxAge,
+		clk:    clk,
+	}, nil
+}
+
+func (c *BacklogChecker) Check(ctx context.Context) (map[string]any, error) {
+	backlog, err := c.reader.GetBacklog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	age := backlog.Age(c.clk.Now().UTC())
+	details := map[string]any{
+		"pending":       backlog.Count,
+		"oldestAgeSecs": age.Seconds(),
+		"quarantined":   backlog.Quarantined,
+	}
+	if age > c.maxAge {
+		return details, fmt.Errorf("oldest outbox message is pending for %s", age.Round(time.Second))
+	}
+	return details, nil
+}
@@ -0,0 +1,74 @@
+package outbox
+
+import (
+	"context"
+	"delivery/internal/pkg/clock"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBacklogReader struct {
+	backlog Backlog
+	err     error
+}
+
+func (r fakeBacklogReader) GetBacklog(context.Context) (Backlog, error) {
+	return r.backlog, r.err
+}
+
+func TestNewBacklogChecker(t *testing.T) {
+	_, err := NewBacklogChecker(nil, time.Minute, clock.System())
+	assert.Error(t, err)
+
+	_, err = NewBacklogChecker(fakeBacklogReader{}, 0, clock.System())
+	assert.Error(t, err)
+
+	_, err = NewBacklogChecker(fakeBacklogReader{}, time.Minute, nil)
+	assert.Error(t, err)
+}
+
+func TestBacklogChecker_Check(t *testing.T) {
+	now := clock.NewFake(testNow)
+	fresh := testNow.Add(-time.Second)
+	stale := testNow.Add(-time.Hour)
+
+	t.Run("empty outbox", func(t *testing.T) {
+		checker, err := NewBacklogChecker(fakeBacklogReader{}, time.Minute, now)
+		require.NoError(t, err)
+
+		details, err := checker.Check(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), details["pending"])
+	})
+
+	t.Run("recent messages", func(t *testing.T) {
+		checker, err := NewBacklogChecker(fakeBacklogReader{backlog: Backlog{Count: 3, OldestOccurredAtUtc: &fresh}}, time.Minute, now)
+		require.NoError(t, err)
+
+		details, err := checker.Check(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), details["pending"])
+	})
+
+	t.Run("stale messages", func(t *testing.T) {
+		checker, err := NewBacklogChecker(fakeBacklogReader{backlog: Backlog{Count: 1, OldestOccurredAtUtc: &stale}}, time.Minute, now)
+		require.NoError(t, err)
+
+		details, err := checker.Check(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, time.Hour.Seconds(), details["oldestAgeSecs"])
+	})
+
+	t.Run("reader error", func(t *testing.T) {
+		readErr := errors.New("db is down")
+		checker, err := NewBacklogChecker(fakeBacklogReader{err: readErr}, time.Minute, now)
+		require.NoError(t, err)
+
+		_, err = checker.Check(context.Background())
+		assert.ErrorIs(t, err, readErr)
+	})
+}
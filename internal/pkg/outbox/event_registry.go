@@ -1,16 +1,17 @@
 package outbox
 
 import (
+	"delivery/internal/pkg/clock"
 	"delivery/internal/pkg/ddd"
 	"delivery/internal/pkg/errs"
 	"encoding/json"
 	"fmt"
 	"reflect"
-	"time"
 )
 
 type EventRegistry interface {
 	RegisterDomainEvent(eventType reflect.Type) error
+	IsRegistered(eventName string) bool
 	DecodeDomainEvent(event *Message) (ddd.DomainEvent, error)
 }
 
@@ -35,7 +36,18 @@ func (r *eventRegistry) RegisterDomainEvent(eventType reflect.Type) error {
 	return nil
 }
 
-func EncodeDomainEvent(domainEvent ddd.DomainEvent) (Message, error) {
+func (r *eventRegistry) IsRegistered(eventName string) bool {
+	_, ok := r.EventRegistry[eventName]
+	return ok
+}
+
+// EncodeDomainEvent готовит сообщение outbox. Время события берется из clk,
+// чтобы повторный прогон давал те же сообщения.
+func EncodeDomainEvent(domainEvent ddd.DomainEvent, clk clock.Clock) (Message, error) {
+	if clk == nil {
+		return Message{}, errs.NewValueIsRequiredError("clk")
+	}
+
 	payload, err := json.Marshal(domainEvent)
 	if err != nil {
 		return Message{}, fmt.Errorf("failed to marshal event: %w", err)
@@ -45,7 +57,7 @@ func EncodeDomainEvent(domainEvent ddd.DomainEvent) (Message, error) {
 		ID:             domainEvent.GetID(),
 		Name:           domainEvent.GetName(),
 		Payload:        payload,
-		OccurredAtUtc:  time.Now().UTC(),
+		OccurredAtUtc:  clk.Now().UTC(),
 		ProcessedAtUtc: nil,
 	}, nil
 }
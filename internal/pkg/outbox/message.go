@@ -6,11 +6,28 @@ import (
 )
 
 type Message struct {
-	ID             uuid.UUID
-	Name           string
-	Payload        []byte
-	OccurredAtUtc  time.Time
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name           string    `gorm:"type:varchar(255)"`
+	Payload        []byte    `gorm:"type:jsonb"`
+	OccurredAtUtc  time.Time `gorm:"index"`
 	ProcessedAtUtc *time.Time
+	// Sequence — порядковый номер сообщения, его назначает база при вставке.
+	// События одной транзакции делят OccurredAtUtc, поэтому порядок публикации
+	// задает только Sequence.
+	Sequence int64 `gorm:"->"`
+	// TraceContext — контекст трассировки транзакции, сохранившей событие.
+	// Relay продолжает с него трассу, хотя публикует событие позже и в другой
+	// горутине.
+	TraceContext map[string]string `gorm:"type:jsonb;serializer:json"`
+	// CorrelationID — цепочка, в которой событие возникло. Relay восстанавливает
+	// ее в контексте, и она уходит дальше в заголовках сообщений Kafka.
+	CorrelationID string `gorm:"type:varchar(64)"`
+	// Attempts и LastError — неудачные попытки публикации. После исчерпания
+	// попыток сообщение помещается в карантин (QuarantinedAtUtc) и больше не
+	// блокирует очередь; вернуть его можно, сбросив поле вручную.
+	Attempts         int
+	LastError        string `gorm:"type:text"`
+	QuarantinedAtUtc *time.Time
 }
 
 func (Message) TableName() string {
@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"context"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/correlation"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/tracing"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+type Relay struct {
+	repository  Repository
+	registry    EventRegistry
+	mediatr     ddd.Mediatr
+	batchSize   int
+	maxAttempts int
+	clock       clock.Clock
+}
+
+func NewRelay(repository Repository, registry EventRegistry, mediatr ddd.Mediatr, batchSize int, maxAttempts int, clk clock.Clock) (*Relay, error) {
+	if repository == nil {
+		return nil, errs.NewValueIsRequiredError("repository")
+	}
+	if registry == nil {
+		return nil, errs.NewValueIsRequiredError("registry")
+	}
+	if mediatr == nil {
+		return nil, errs.NewValueIsRequiredError("mediatr")
+	}
+	if batchSize <= 0 {
+		return nil, errs.NewValueIsOutOfRangeError("batchSize", batchSize, 1, math.MaxInt)
+	}
+	if maxAttempts <= 0 {
+		return nil, errs.NewValueIsOutOfRangeError("maxAttempts", maxAttempts, 1, math.MaxInt)
+	}
+	if clk == nil {
+		return nil, errs.NewValueIsRequiredError("clk")
+	}
+
+	return &Relay{
+		repository:  repository,
+		registry:    registry,
+		mediatr:     mediatr,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		clock:       clk,
+	}, nil
+}
+
+// PublishPending публикует одну пачку необработанных сообщений в порядке их
+// появления. Пачка выбирается и обрабатывается в одной транзакции, строки
+// заблокированы (SKIP LOCKED), поэтому несколько реплик не публикуют одно и то
+// же сообщение. На первой ошибке обработка пачки прерывается, чтобы не нарушить
+// порядок событий: неудачная попытка записывается, и сообщение будет повторно
+// опубликовано на следующем запуске, а после maxAttempts попыток уйдет в
+// карантин и перестанет задерживать остальные.
+func (r *Relay) PublishPending(ctx context.Context) (int, error) {
+	published := 0
+	var publishErr error
+
+	err := r.repository.InTx(ctx, func(ctx context.Context, repository Repository) error {
+		messages, err := repository.GetNotPublishedMessages(ctx, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if publishErr = r.publish(ctx, repository, message); publishErr != nil {
+				publishErr = r.recordFailure(ctx, repository, message, publishErr)
+				return nil
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, publishErr
+}
+
+// recordFailure увеличивает счетчик попыток и при их исчерпании помещает
+// сообщение в карантин
+func (r *Relay) recordFailure(ctx context.Context, repository Repository, message *Message, publishErr error) error {
+	attempts := message.Attempts + 1
+	var quarantinedAtUtc *time.Time
+	if attempts >= r.maxAttempts {
+		now := r.clock.Now().UTC()
+		quarantinedAtUtc = &now
+		publishErr = fmt.Errorf("%w (quarantined after %d attempts)", publishErr, attempts)
+	}
+
+	if err := repository.MarkAsFailed(ctx, message.ID, attempts, publishErr.Error(), quarantinedAtUtc); err != nil {
+		return errors.Join(publishErr, err)
+	}
+	return publishErr
+}
+
+// publish продолжает трассу и цепочку correlation ID, в которых событие было
+// сохранено, поэтому обработчики (например, публикация в Kafka) попадают в них же
+func (r *Relay) publish(ctx context.Context, repository Repository, message *Message) (err error) {
+	ctx = correlation.WithCorrelationID(tracing.Extract(ctx, message.TraceContext), message.CorrelationID)
+	ctx, span := tracing.Start(ctx, "outbox publish "+message.Name)
+	defer func() { tracing.End(span, err) }()
+
+	event, err := r.registry.DecodeDomainEvent(message)
+	if err != nil {
+		return fmt.Errorf("outbox message %s: %w", message.ID, err)
+	}
+
+	if err := r.mediatr.Publish(ctx, event); err != nil {
+		return fmt.Errorf("outbox message %s: %w", message.ID, err)
+	}
+
+	return repository.MarkAsProcessed(ctx, message.ID, r.clock.Now().UTC())
+}
@@ -0,0 +1,230 @@
+package outbox
+
+import (
+	"context"
+	"delivery/internal/pkg/clock"
+	"delivery/internal/pkg/correlation"
+	"delivery/internal/pkg/ddd"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TestEvent struct {
+	ID    uuid.UUID
+	Value string
+}
+
+func (e TestEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e TestEvent) GetName() string {
+	return "TestEvent"
+}
+
+type inMemoryRepository struct {
+	messages []*Message
+	inTx     bool
+}
+
+func (r *inMemoryRepository) InTx(ctx context.Context, fn func(ctx context.Context, repository Repository) error) error {
+	r.inTx = true
+	defer func() { r.inTx = false }()
+	return fn(ctx, r)
+}
+
+func (r *inMemoryRepository) GetNotPublishedMessages(_ context.Context, limit int) ([]*Message, error) {
+	if !r.inTx {
+		return nil, errors.New("messages are read outside of transaction")
+	}
+	var result []*Message
+	for _, message := range r.messages {
+		if message.ProcessedAtUtc == nil && message.QuarantinedAtUtc == nil && len(result) < limit {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+func (r *inMemoryRepository) MarkAsProcessed(_ context.Context, ID uuid.UUID, processedAtUtc time.Time) error {
+	for _, message := range r.messages {
+		if message.ID == ID {
+			message.ProcessedAtUtc = &processedAtUtc
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+func (r *inMemoryRepository) MarkAsFailed(_ context.Context, ID uuid.UUID, attempts int, lastError string, quarantinedAtUtc *time.Time) error {
+	for _, message := range r.messages {
+		if message.ID == ID {
+			message.Attempts = attempts
+			message.LastError = lastError
+			message.QuarantinedAtUtc = quarantinedAtUtc
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+type recordingHandler struct {
+	events []ddd.DomainEvent
+	ctxs   []context.Context
+	failOn string
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, event ddd.DomainEvent) error {
+	h.ctxs = append(h.ctxs, ctx)
+	if testEvent, ok := event.(*TestEvent); ok && testEvent.Value == h.failOn {
+		return errors.New("handler failed")
+	}
+	h.events = append(h.events, event)
+	return nil
+}
+
+var testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+const testMaxAttempts = 3
+
+func setupRelay(t *testing.T, handler *recordingHandler, batchSize int, values ...string) (*Relay, *inMemoryRepository) {
+	registry, err := NewEventRegistry()
+	require.NoError(t, err)
+	require.NoError(t, registry.RegisterDomainEvent(reflect.TypeOf(TestEvent{})))
+
+	mediatr := ddd.NewMediatr()
+	mediatr.Subscribe(handler, TestEvent{})
+
+	repository := &inMemoryRepository{}
+	for _, value := range values {
+		message, err := EncodeDomainEvent(TestEvent{ID: uuid.New(), Value: value}, clock.NewFake(testNow))
+		require.NoError(t, err)
+		repository.messages = append(repository.messages, &message)
+	}
+
+	relay, err := NewRelay(repository, registry, mediatr, batchSize, testMaxAttempts, clock.NewFake(testNow.Add(time.Minute)))
+	require.NoError(t, err)
+	return relay, repository
+}
+
+func TestRelay_PublishPending(t *testing.T) {
+	t.Run("publishes batch and marks messages as processed", func(t *testing.T) {
+		handler := &recordingHandler{}
+		relay, repository := setupRelay(t, handler, 2, "a", "b", "c")
+
+		published, err := relay.PublishPending(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, published)
+		require.Len(t, handler.events, 2)
+		assert.Equal(t, "a", handler.events[0].(*TestEvent).Value)
+		assert.Equal(t, testNow, repository.messages[0].OccurredAtUtc)
+		require.NotNil(t, repository.messages[0].ProcessedAtUtc)
+		assert.Equal(t, testNow.Add(time.Minute), *repository.messages[0].ProcessedAtUtc)
+		assert.NotNil(t, repository.messages[1].ProcessedAtUtc)
+		assert.Nil(t, repository.messages[2].ProcessedAtUtc)
+
+		published, err = relay.PublishPending(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, published)
+	})
+
+	t.Run("stops on failure and leaves message pending", func(t *testing.T) {
+		handler := &recordingHandler{failOn: "b"}
+		relay, repository := setupRelay(t, handler, 10, "a", "b", "c")
+
+		published, err := relay.PublishPending(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, 1, published)
+		assert.NotNil(t, repository.messages[0].ProcessedAtUtc)
+		assert.Nil(t, repository.messages[1].ProcessedAtUtc)
+		assert.Equal(t, 1, repository.messages[1].Attempts)
+		assert.Contains(t, repository.messages[1].LastError, "handler failed")
+		assert.Nil(t, repository.messages[1].QuarantinedAtUtc)
+		assert.Nil(t, repository.messages[2].ProcessedAtUtc)
+	})
+
+	t.Run("quarantines message after max attempts", func(t *testing.T) {
+		handler := &recordingHandler{failOn: "b"}
+		relay, repository := setupRelay(t, handler, 10, "a", "b", "c")
+
+		for range testMaxAttempts - 1 {
+			_, err := relay.PublishPending(context.Background())
+			require.Error(t, err)
+			assert.Nil(t, repository.messages[1].QuarantinedAtUtc)
+		}
+
+		_, err := relay.PublishPending(context.Background())
+		assert.ErrorContains(t, err, "quarantined")
+		assert.Equal(t, testMaxAttempts, repository.messages[1].Attempts)
+		require.NotNil(t, repository.messages[1].QuarantinedAtUtc)
+		assert.Equal(t, testNow.Add(time.Minute), *repository.messages[1].QuarantinedAtUtc)
+
+		published, err := relay.PublishPending(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, published)
+		assert.NotNil(t, repository.messages[2].ProcessedAtUtc)
+		assert.Nil(t, repository.messages[1].ProcessedAtUtc)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		handler := &recordingHandler{}
+		relay, repository := setupRelay(t, handler, 10)
+		repository.messages = append(repository.messages, &Message{ID: uuid.New(), Name: "Unknown"})
+
+		_, err := relay.PublishPending(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Unknown")
+	})
+}
+
+func TestRelay_ContinuesTraceAndCorrelationOfSavedEvent(t *testing.T) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	handler := &recordingHandler{}
+	relay, repository := setupRelay(t, handler, 10, "a")
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "POST /api/v1/orders")
+	span.End()
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	repository.messages[0].TraceContext = carrier
+	repository.messages[0].CorrelationID = "checkout-1"
+
+	_, err := relay.PublishPending(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, handler.ctxs, 1)
+	published := trace.SpanContextFromContext(handler.ctxs[0])
+	assert.Equal(t, span.SpanContext().TraceID(), published.TraceID())
+	assert.NotEqual(t, span.SpanContext().SpanID(), published.SpanID())
+	assert.Equal(t, "checkout-1", correlation.CorrelationID(handler.ctxs[0]))
+	assert.Equal(t, repository.messages[0].ID.String(), correlation.CausationID(handler.ctxs[0]))
+}
+
+func TestNewRelay(t *testing.T) {
+	registry, err := NewEventRegistry()
+	require.NoError(t, err)
+
+	_, err = NewRelay(nil, registry, ddd.NewMediatr(), 1, 1, clock.System())
+	assert.Error(t, err)
+
+	_, err = NewRelay(&inMemoryRepository{}, registry, ddd.NewMediatr(), 0, 1, clock.System())
+	assert.Error(t, err)
+
+	_, err = NewRelay(&inMemoryRepository{}, registry, ddd.NewMediatr(), 1, 0, clock.System())
+	assert.Error(t, err)
+
+	_, err = NewRelay(&inMemoryRepository{}, registry, ddd.NewMediatr(), 1, 1, nil)
+	assert.Error(t, err)
+}
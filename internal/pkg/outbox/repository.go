@@ -0,0 +1,19 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// InTx выполняет fn в одной транзакции: сообщения, выбранные через
+	// переданный в fn репозиторий, остаются заблокированными до ее конца
+	InTx(ctx context.Context, fn func(ctx context.Context, repository Repository) error) error
+	// GetNotPublishedMessages пропускает сообщения в карантине и строки,
+	// которые уже заблокировала другая реплика relay
+	GetNotPublishedMessages(ctx context.Context, limit int) ([]*Message, error)
+	MarkAsProcessed(ctx context.Context, ID uuid.UUID, processedAtUtc time.Time) error
+	MarkAsFailed(ctx context.Context, ID uuid.UUID, attempts int, lastError string, quarantinedAtUtc *time.Time) error
+}
@@ -0,0 +1,88 @@
+package random
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Source выдает псевдослучайные числа. Код, которому нужна случайность,
+// получает Source через конструктор: с одинаковым seed прогон повторяется.
+type Source interface {
+	// Intn возвращает число из [0, n), n > 0
+	Intn(n int) int
+	// Read заполняет p случайными байтами, чтобы из источника можно было
+	// генерировать идентификаторы
+	Read(p []byte) (int, error)
+}
+
+var (
+	_ Source = &seededSource{}
+	_ Source = &Fake{}
+)
+
+// seededSource защищает rand.Rand мьютексом: он не рассчитан на вызовы из
+// нескольких горутин, а обработчики команд работают параллельно
+type seededSource struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewSeeded возвращает детерминированный источник: одинаковый seed дает
+// одинаковую последовательность
+func NewSeeded(seed int64) Source {
+	return &seededSource{rand: rand.New(rand.NewSource(seed))}
+}
+
+// System возвращает источник, засеянный текущим временем
+func System() Source {
+	return NewSeeded(time.Now().UnixNano())
+}
+
+func (s *seededSource) Intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Intn(n)
+}
+
+func (s *seededSource) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Read(p)
+}
+
+// Fake возвращает заданные значения по кругу, каждое по модулю n
+type Fake struct {
+	mu     sync.Mutex
+	values []int
+	next   int
+}
+
+func NewFake(values ...int) *Fake {
+	return &Fake{values: values}
+}
+
+func (f *Fake) Intn(n int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.values) == 0 {
+		return 0
+	}
+	value := f.values[f.next%len(f.values)]
+	f.next++
+
+	value %= n
+	if value < 0 {
+		value += n
+	}
+	return value
+}
+
+// Read заполняет p байтами из заданных значений по кругу
+func (f *Fake) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(f.Intn(256))
+	}
+	return len(p), nil
+}
@@ -0,0 +1,52 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSeeded(t *testing.T) {
+	first, second := NewSeeded(7), NewSeeded(7)
+
+	for range 100 {
+		value := first.Intn(10)
+		assert.Equal(t, value, second.Intn(10))
+		assert.GreaterOrEqual(t, value, 0)
+		assert.Less(t, value, 10)
+	}
+}
+
+func TestNewSeeded_Read(t *testing.T) {
+	first, second := NewSeeded(7), NewSeeded(7)
+	firstBytes, secondBytes := make([]byte, 16), make([]byte, 16)
+
+	n, err := first.Read(firstBytes)
+	require.NoError(t, err)
+	assert.Equal(t, 16, n)
+	_, err = second.Read(secondBytes)
+	require.NoError(t, err)
+	assert.Equal(t, firstBytes, secondBytes)
+}
+
+func TestFake(t *testing.T) {
+	fake := NewFake(3, 12, -1)
+
+	assert.Equal(t, 3, fake.Intn(10))
+	assert.Equal(t, 2, fake.Intn(10))
+	assert.Equal(t, 9, fake.Intn(10))
+	assert.Equal(t, 3, fake.Intn(10))
+
+	assert.Equal(t, 0, NewFake().Intn(10))
+}
+
+func TestFake_Read(t *testing.T) {
+	p := make([]byte, 4)
+
+	n, err := NewFake(1, 258, -1).Read(p)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []byte{1, 2, 255, 1}, p)
+}
@@ -2,7 +2,10 @@ package testcnts
 
 import (
 	"context"
+	"database/sql"
+	"delivery/internal/adapters/out/postgres/migrations"
 	"fmt"
+	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"time"
@@ -36,5 +39,27 @@ func StartPostgresContainer(ctx context.Context) (testcontainers.Container, stri
 
 	dsn := fmt.Sprintf("postgres://testuser:testpass@%s:%s/testdb?sslmode=disable", host, port.Port())
 
+	// Схема БД создается теми же миграциями, что и в приложении
+	if err := migrate(ctx, dsn); err != nil {
+		_ = postgresContainer.Terminate(ctx)
+		return nil, "", err
+	}
+
 	return postgresContainer, dsn, nil
 }
+
+func migrate(ctx context.Context, dsn string) error {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	_, err = migrator.Up(ctx)
+	return err
+}
@@ -0,0 +1,29 @@
+package tracing
+
+import (
+	"context"
+	"delivery/internal/pkg/ddd"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// HandlerDecorator открывает на вызов обработчика use case span с его именем.
+// Use cases при этом ничего не знают о трассировке. Ошибки из expected — штатный
+// исход (например, нет свободного курьера): span не помечается ошибкой, а
+// причина сохраняется в атрибуте outcome.
+func HandlerDecorator(expected ...error) ddd.HandlerDecorator {
+	return func(ctx context.Context, name string, next func(ctx context.Context) error) error {
+		ctx, span := Start(ctx, name)
+		err := next(ctx)
+		for _, target := range expected {
+			if errors.Is(err, target) {
+				span.SetAttributes(attribute.String("outcome", err.Error()))
+				End(span, nil)
+				return err
+			}
+		}
+		End(span, err)
+		return err
+	}
+}
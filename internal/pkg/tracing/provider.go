@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"delivery/internal/pkg/errs"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Куда отправлять spans
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOtlp   = "otlp"
+)
+
+var Exporters = []string{ExporterNone, ExporterStdout, ExporterOtlp}
+
+// shutdownTimeout ограничивает отправку накопленных spans при остановке
+const shutdownTimeout = 5 * time.Second
+
+type Config struct {
+	ServiceName  string
+	Exporter     string
+	OtlpEndpoint string
+}
+
+// Provider настраивает глобальные TracerProvider и propagator. Propagator
+// включен и без экспорта: тогда трасса, начатая вызывающим сервисом,
+// передается дальше через Kafka и outbox без изменений.
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+}
+
+func NewProvider(ctx context.Context, config Config) (*Provider, error) {
+	if config.ServiceName == "" {
+		return nil, errs.NewValueIsRequiredError("serviceName")
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch config.Exporter {
+	case ExporterNone:
+		return &Provider{}, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOtlp:
+		if config.OtlpEndpoint == "" {
+			return nil, errs.NewValueIsRequiredError("otlpEndpoint")
+		}
+		exporter, err = otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(config.OtlpEndpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+	default:
+		return nil, errs.NewValueIsInvalidError("exporter")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s exporter: %w", config.Exporter, err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(config.ServiceName))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return &Provider{
+		tracerProvider: tracerProvider,
+	}, nil
+}
+
+// Close отправляет накопленные spans и останавливает экспорт
+func (p *Provider) Close() error {
+	if p.tracerProvider == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return p.tracerProvider.Shutdown(ctx)
+}
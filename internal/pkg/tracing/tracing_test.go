@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return recorder
+}
+
+func TestInjectExtract(t *testing.T) {
+	setupRecorder(t)
+
+	assert.Nil(t, Inject(context.Background()))
+
+	ctx, span := Start(context.Background(), "parent")
+	defer span.End()
+
+	traceContext := Inject(ctx)
+	require.Contains(t, traceContext, "traceparent")
+
+	restored := trace.SpanContextFromContext(Extract(context.Background(), traceContext))
+	assert.Equal(t, span.SpanContext().TraceID(), restored.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), restored.SpanID())
+}
+
+func TestHandlerDecorator(t *testing.T) {
+	recorder := setupRecorder(t)
+	handlerErr := errors.New("db is down")
+	var handledCtx context.Context
+
+	err := HandlerDecorator()(context.Background(), "CreateOrderCommandHandler", func(ctx context.Context) error {
+		handledCtx = ctx
+		return handlerErr
+	})
+
+	assert.ErrorIs(t, err, handlerErr)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "CreateOrderCommandHandler", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, spans[0].SpanContext().SpanID(), trace.SpanContextFromContext(handledCtx).SpanID())
+}
+
+func TestHandlerDecorator_ExpectedError(t *testing.T) {
+	recorder := setupRecorder(t)
+	expected := errors.New("no suitable courier for order")
+	handlerErr := fmt.Errorf("%w: 3 orders", expected)
+
+	err := HandlerDecorator(expected)(context.Background(), "AssignOrdersCommandHandler", func(context.Context) error {
+		return handlerErr
+	})
+
+	assert.ErrorIs(t, err, expected)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Empty(t, spans[0].Events())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("outcome", handlerErr.Error()))
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(context.Background(), Config{ServiceName: "delivery", Exporter: ExporterNone})
+	require.NoError(t, err)
+	assert.NoError(t, provider.Close())
+
+	_, err = NewProvider(context.Background(), Config{ServiceName: "delivery", Exporter: "jaeger"})
+	assert.Error(t, err)
+
+	_, err = NewProvider(context.Background(), Config{Exporter: ExporterNone})
+	assert.Error(t, err)
+}